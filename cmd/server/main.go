@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	// "os"
@@ -18,6 +23,9 @@ import (
 	consulapi "github.com/hashicorp/consul/api"
 )
 
+// shutdownTimeout thời gian tối đa chờ request đang xử lý khi tắt server
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	log.Printf("Starting server...")
 	filePath := os.Args[1]
@@ -49,11 +57,34 @@ func main() {
 	//db
 	db.ConnectMongoDB()
 
-	r := router.SetupRouter(consulClient, db.ServiceCollection, db.ServiceGroupCollection)
+	r, background := router.SetupRouter(consulClient, db.MongoDatabase)
 	port := cfg.Server.Port
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to run server:", err)
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: r,
+	}
+	// SSE giữ kết nối lâu, đóng stream ngay khi bắt đầu tắt để Shutdown không phải chờ
+	srv.RegisterOnShutdown(background.CloseStreams)
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to run server:", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Printf("Shutting down server...")
+
+	// chờ request đang xử lý xong rồi mới dừng worker, click và event còn trong bộ nhớ được ghi nốt
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown failed: %v", err)
 	}
+	background.Stop()
+	log.Printf("Server stopped")
 }
 
 func waitPassing(cli *consulapi.Client, name string, timeout time.Duration) error {
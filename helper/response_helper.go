@@ -1,6 +1,9 @@
 package helper
 
 import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
 	"services-management/logger"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ const (
 	ErrInvalidRequest   = "ERR_INVALID_REQUEST"
 	ErrNotFound         = "ERR_NOT_FOUND"
 	ErrInternal         = "ERR_INTERNAL"
+	ErrUnauthorized     = "ERR_UNAUTHORIZED"
 )
 
 type APIResponse struct {
@@ -52,3 +56,20 @@ func SendError(c *gin.Context, statusCode int, err error, errorCode string) {
 		ErrorCode:  errorCode,
 	})
 }
+
+// SendCSV trả file csv để tải về, header là dòng tiêu đề
+func SendCSV(c *gin.Context, filename string, header []string, rows [][]string) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(header)
+	_ = w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		logger.WriteLogEx("error", "write csv failed", map[string]interface{}{
+			"path":  c.Request.URL.Path,
+			"error": err.Error(),
+		})
+	}
+}
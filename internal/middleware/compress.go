@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// content type đáng nén, ảnh/file nhị phân và event-stream (cần flush từng event) giữ nguyên
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"image/svg+xml",
+	"text/html",
+	"text/plain",
+	"text/css",
+	"text/csv",
+}
+
+var (
+	gzipPool   = sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+	brotliPool = sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, 4) }}
+)
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Compress nén response bằng brotli hoặc gzip theo Accept-Encoding của client (ưu tiên brotli)
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = w
+		defer w.close()
+
+		c.Next()
+	}
+}
+
+// compressWriter quyết định nén ở lần ghi đầu tiên, khi handler đã đặt Content-Type
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	encoder  encoder
+	decided  bool
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.decide()
+	}
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.encoder.Write(b)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Flush() {
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) decide() {
+	w.decided = true
+
+	header := w.Header()
+	status := w.Status()
+	if header.Get("Content-Encoding") != "" || !compressible(header.Get("Content-Type")) ||
+		status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return
+	}
+
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	if w.encoding == encodingBrotli {
+		w.encoder = brotliPool.Get().(*brotli.Writer)
+	} else {
+		w.encoder = gzipPool.Get().(*gzip.Writer)
+	}
+	w.encoder.Reset(w.ResponseWriter)
+}
+
+func (w *compressWriter) close() {
+	if w.encoder == nil {
+		return
+	}
+	_ = w.encoder.Close()
+	w.encoder.Reset(io.Discard)
+	if w.encoding == encodingBrotli {
+		brotliPool.Put(w.encoder)
+	} else {
+		gzipPool.Put(w.encoder)
+	}
+	w.encoder = nil
+}
+
+func compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	for _, t := range compressibleTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateEncoding chọn encoding có q cao nhất trong br/gzip, bằng nhau thì lấy br, không nhận cái nào thì trả về rỗng
+func negotiateEncoding(acceptEncoding string) string {
+	quality := map[string]float64{}
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if name == "*" {
+			wildcard = q
+		} else if name != "" {
+			quality[name] = q
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range []string{encodingBrotli, encodingGzip} {
+		q, ok := quality[encoding]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConditionalGet gắn ETag theo nội dung response 200 của GET, trả 304 khi khớp If-None-Match.
+// Response được giữ trong bộ nhớ tới khi handler xong nên chỉ dùng cho API trả JSON vừa phải
+func ConditionalGet(cacheControl string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.status != http.StatusOK {
+			w.ResponseWriter.WriteHeader(w.status)
+			_, _ = w.ResponseWriter.Write(w.body.Bytes())
+			return
+		}
+
+		// weak ETag vì cùng nội dung có thể được nén khác nhau
+		sum := sha256.Sum256(w.body.Bytes())
+		etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+		header := w.ResponseWriter.Header()
+		header.Set("ETag", etag)
+		header.Set("Cache-Control", cacheControl)
+		header.Add("Vary", "Authorization, Accept-Language")
+
+		if etagMatch(c.GetHeader("If-None-Match"), etag) {
+			header.Del("Content-Type")
+			w.ResponseWriter.WriteHeader(http.StatusNotModified)
+			w.ResponseWriter.WriteHeaderNow()
+			return
+		}
+		w.ResponseWriter.WriteHeader(http.StatusOK)
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
+
+// bufferedWriter giữ lại status và body để tính ETag trước khi gửi
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+func (w *bufferedWriter) Flush() {}
+
+// etagMatch so sánh weak theo RFC 9110: bỏ tiền tố W/ ở cả hai phía
+func etagMatch(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
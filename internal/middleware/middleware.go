@@ -94,3 +94,47 @@ func RequireAdmin() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// Environment gắn môi trường đang chạy vào context, header (nếu bật) cho tester chọn môi trường khác
+func Environment(defaultEnv, overrideHeader string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		env := defaultEnv
+		if overrideHeader != "" {
+			if v := strings.TrimSpace(c.GetHeader(overrideHeader)); v != "" {
+				env = strings.ToLower(v)
+			}
+		}
+
+		c.Set(constants.Environment.String(), env)
+		ctx := context.WithValue(c.Request.Context(), constants.Environment, env)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// RequireUser chặn token không có user_id (các API theo từng user)
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(constants.UserID.String()) == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found in token"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// QueryToken lấy token từ query param khi request không có header Authorization,
+// dùng cho client không tự đặt header được như EventSource
+func QueryToken(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query(param); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"services-management/logger"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const defaultTTL = time.Minute
+
+// Cache read-through theo namespace: miss thì chỉ một request load cho mỗi key (single-flight),
+// các request cùng key đợi và dùng chung kết quả. Store lỗi thì load thẳng, không làm hỏng request
+type Cache struct {
+	store     Store
+	namespace string
+	ttl       time.Duration
+	group     singleflight.Group
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	shared atomic.Int64
+	errors atomic.Int64
+}
+
+type Stats struct {
+	Backend  string  `json:"backend"`
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	Shared   int64   `json:"shared"` // miss dùng chung kết quả load của request khác
+	Errors   int64   `json:"errors"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+func New(store Store, namespace string, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Cache{
+		store:     store,
+		namespace: namespace,
+		ttl:       ttl,
+	}
+}
+
+// Fetch đọc key từ cache, miss thì gọi load rồi lưu lại. Cache nil thì luôn gọi load
+func Fetch[T any](ctx context.Context, c *Cache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	version, err := c.store.Version(ctx, c.namespace)
+	if err != nil {
+		c.fail("read cache version failed", err)
+		return load(ctx)
+	}
+	fullKey := c.namespace + ":" + strconv.FormatInt(version, 10) + ":" + key
+
+	if cached, ok, err := c.store.Get(ctx, fullKey); err != nil {
+		c.fail("read cache failed", err)
+	} else if ok {
+		var value T
+		if err := json.Unmarshal(cached, &value); err == nil {
+			c.hits.Add(1)
+			return value, nil
+		}
+	}
+
+	c.misses.Add(1)
+	loaded := false
+	value, err, shared := c.group.Do(fullKey, func() (any, error) {
+		loaded = true
+		value, err := load(ctx)
+		if err != nil {
+			return value, err
+		}
+
+		if encoded, err := json.Marshal(value); err != nil {
+			c.fail("encode cache value failed", err)
+		} else if err := c.store.Set(ctx, fullKey, encoded, c.ttl); err != nil {
+			c.fail("write cache failed", err)
+		}
+		return value, nil
+	})
+	if shared && !loaded {
+		c.shared.Add(1)
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// Invalidate vô hiệu mọi key của namespace
+func (c *Cache) Invalidate(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	if err := c.store.Bump(ctx, c.namespace); err != nil {
+		c.fail("invalidate cache failed", err)
+	}
+}
+
+func (c *Cache) Stats() Stats {
+	if c == nil {
+		return Stats{Backend: "disabled"}
+	}
+	stats := Stats{
+		Backend: c.store.Backend(),
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Shared:  c.shared.Load(),
+		Errors:  c.errors.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+func (c *Cache) fail(message string, err error) {
+	c.errors.Add(1)
+	logger.WriteLogEx("warn", message, map[string]any{
+		"namespace": c.namespace,
+		"error":     err.Error(),
+	})
+}
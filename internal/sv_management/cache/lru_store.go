@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+type lruEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// lruStore cache trong process, mỗi instance giữ bản riêng nên thay đổi ghi qua instance khác
+// chỉ được thấy sau khi hết ttl
+type lruStore struct {
+	entries  *lru.Cache
+	mu       sync.RWMutex
+	versions map[string]int64
+}
+
+func NewLRUStore(size int) (Store, error) {
+	entries, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &lruStore{
+		entries:  entries,
+		versions: make(map[string]int64),
+	}, nil
+}
+
+func (s *lruStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	value, ok := s.entries.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	entry := value.(lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.entries.Remove(key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *lruStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.entries.Add(key, lruEntry{value: value, expiresAt: time.Now().Add(ttl)})
+	return nil
+}
+
+func (s *lruStore) Version(_ context.Context, namespace string) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.versions[namespace], nil
+}
+
+// Bump xoá luôn mọi entry vì key của thế hệ cũ không còn được đọc tới
+func (s *lruStore) Bump(_ context.Context, namespace string) error {
+	s.mu.Lock()
+	s.versions[namespace]++
+	s.mu.Unlock()
+
+	s.entries.Purge()
+	return nil
+}
+
+func (s *lruStore) Backend() string {
+	return "lru"
+}
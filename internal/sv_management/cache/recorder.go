@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+)
+
+type invalidatingRecorder struct {
+	next   events.Recorder
+	caches []*Cache
+}
+
+// InvalidatingRecorder vô hiệu các cache sau mỗi thay đổi catalog đã ghi thành công
+func InvalidatingRecorder(next events.Recorder, caches ...*Cache) events.Recorder {
+	return &invalidatingRecorder{
+		next:   next,
+		caches: caches,
+	}
+}
+
+func (r *invalidatingRecorder) Record(ctx context.Context, write func(ctx context.Context) ([]events.Event, error)) error {
+	if err := r.next.Record(ctx, write); err != nil {
+		return err
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	for _, c := range r.caches {
+		c.Invalidate(ctx)
+	}
+	return nil
+}
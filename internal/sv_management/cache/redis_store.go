@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStore cache dùng chung giữa các instance, Bump ở một instance vô hiệu cache của mọi instance
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisStore(addr, password string, db int, prefix string) Store {
+	return &redisStore{
+		client: redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		}),
+		prefix: prefix,
+	}
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.prefix+key, value, ttl).Err()
+}
+
+func (s *redisStore) Version(ctx context.Context, namespace string) (int64, error) {
+	version, err := s.client.Get(ctx, s.versionKey(namespace)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+// Bump key của thế hệ cũ tự hết hạn theo ttl
+func (s *redisStore) Bump(ctx context.Context, namespace string) error {
+	return s.client.Incr(ctx, s.versionKey(namespace)).Err()
+}
+
+func (s *redisStore) Backend() string {
+	return "redis"
+}
+
+func (s *redisStore) versionKey(namespace string) string {
+	return s.prefix + namespace + ":version"
+}
@@ -0,0 +1,17 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store nơi lưu giá trị đã encode.
+// Version là thế hệ hiện tại của namespace, Bump tăng thế hệ để mọi key cũ không còn được đọc tới
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Version(ctx context.Context, namespace string) (int64, error)
+	Bump(ctx context.Context, namespace string) error
+	// Backend tên backend hiển thị trong thống kê: "lru", "redis"
+	Backend() string
+}
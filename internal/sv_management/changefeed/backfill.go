@@ -0,0 +1,48 @@
+package changefeed
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"time"
+)
+
+// Backfill lần đầu bật delta sync: ghi bản ghi thay đổi cho mọi service/group đang có
+// để client sync từ cursor 0 nhận đủ catalog. Đã có seq thì bỏ qua
+func Backfill(ctx context.Context, changes repository.CatalogChangeRepository, counters repository.CounterRepository, serviceRepo repository.ServiceRepository, groupRepo repository.ServiceGroupRepository) error {
+	current, err := counters.Current(ctx, Counter)
+	if err != nil || current > 0 {
+		return err
+	}
+
+	groups, err := groupRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	services, err := serviceRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tracked := make([]*model.CatalogChange, 0, len(groups)+len(services))
+	for _, g := range groups {
+		tracked = append(tracked, &model.CatalogChange{
+			ID:             EntityGroup + "-" + g.ID.Hex(),
+			EntityType:     EntityGroup,
+			EntityID:       g.ID.Hex(),
+			OrganizationID: g.OrganizationID,
+			ChangedAt:      now,
+		})
+	}
+	for _, svc := range services {
+		tracked = append(tracked, &model.CatalogChange{
+			ID:             EntityService + "-" + svc.ID.Hex(),
+			EntityType:     EntityService,
+			EntityID:       svc.ID.Hex(),
+			OrganizationID: svc.OrganizationID,
+			ChangedAt:      now,
+		})
+	}
+	return Track(ctx, changes, counters, tracked)
+}
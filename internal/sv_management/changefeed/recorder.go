@@ -0,0 +1,121 @@
+package changefeed
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"time"
+)
+
+// Counter tên bộ đếm cấp seq cho thay đổi catalog
+const Counter = "catalog_changes"
+
+const (
+	EntityService = "service"
+	EntityGroup   = "group"
+)
+
+type recorder struct {
+	next     events.Recorder
+	changes  repository.CatalogChangeRepository
+	counters repository.CounterRepository
+}
+
+// NewRecorder ghi bản ghi thay đổi của service/group trong cùng transaction với thay đổi catalog
+func NewRecorder(next events.Recorder, changes repository.CatalogChangeRepository, counters repository.CounterRepository) events.Recorder {
+	return &recorder{
+		next:     next,
+		changes:  changes,
+		counters: counters,
+	}
+}
+
+func (r *recorder) Record(ctx context.Context, write func(ctx context.Context) ([]events.Event, error)) error {
+	return r.next.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		recorded, err := write(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return recorded, Track(ctx, r.changes, r.counters, changesOf(recorded))
+	})
+}
+
+// Track cấp seq theo thứ tự changes rồi ghi lại. Seq nằm trong pending của bộ đếm tới khi ghi xong
+// để Stable không cho cursor vượt qua nó
+func Track(ctx context.Context, changes repository.CatalogChangeRepository, counters repository.CounterRepository, tracked []*model.CatalogChange) error {
+	if len(tracked) == 0 {
+		return nil
+	}
+
+	last, err := counters.Next(ctx, Counter, int64(len(tracked)))
+	if err != nil {
+		return err
+	}
+	first := last - int64(len(tracked)) + 1
+	for i, change := range tracked {
+		change.Seq = first + int64(i)
+	}
+
+	err = changes.Upsert(ctx, tracked)
+	// ghi lỗi cũng bỏ pending, seq không bao giờ được ghi thì không được giữ cursor của client
+	if doneErr := counters.Done(ctx, Counter, first); err == nil {
+		err = doneErr
+	}
+	return err
+}
+
+// Stable seq lớn nhất mà mọi seq nhỏ hơn hoặc bằng nó đã ghi xong, cùng seq lớn nhất đã cấp.
+// Người đọc chỉ được trả thay đổi tới stable: seq đang ghi dở (tắt transaction) có thể commit sau seq lớn hơn nó
+func Stable(ctx context.Context, counters repository.CounterRepository) (stable, current int64, err error) {
+	counter, err := counters.Get(ctx, Counter)
+	if err != nil {
+		return 0, 0, err
+	}
+	stable = counter.Value
+	for _, pending := range counter.Pending {
+		if pending.Seq-1 < stable {
+			stable = pending.Seq - 1
+		}
+	}
+	return stable, counter.Value, nil
+}
+
+// changesOf chuyển event thành thay đổi theo entity, một entity thay đổi nhiều lần chỉ giữ lần cuối
+func changesOf(recorded []events.Event) []*model.CatalogChange {
+	now := time.Now()
+	byID := make(map[string]*model.CatalogChange)
+	result := make([]*model.CatalogChange, 0, len(recorded))
+	add := func(entityType, entityID, organizationID string, deleted bool) {
+		id := entityType + "-" + entityID
+		if change, ok := byID[id]; ok {
+			change.OrganizationID = organizationID
+			change.Deleted = deleted
+			return
+		}
+		change := &model.CatalogChange{
+			ID:             id,
+			EntityType:     entityType,
+			EntityID:       entityID,
+			OrganizationID: organizationID,
+			Deleted:        deleted,
+			ChangedAt:      now,
+		}
+		byID[id] = change
+		result = append(result, change)
+	}
+
+	for _, event := range recorded {
+		switch data := event.Data.(type) {
+		case events.ServiceData:
+			add(EntityService, data.ID, data.OrganizationID, event.Type == events.ServiceDeleted)
+		case events.GroupData:
+			add(EntityGroup, data.ID, data.OrganizationID, event.Type == events.GroupDeleted)
+		case events.ReorderData:
+			for _, groupID := range data.GroupIDs {
+				add(EntityGroup, groupID, data.OrganizationID, false)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package dependency
+
+import (
+	"fmt"
+	"services-management/internal/sv_management/model"
+	"services-management/pkg/constants"
+	"sort"
+	"strings"
+)
+
+// Graph đồ thị phụ thuộc giữa các service, cạnh A -> B nghĩa là A phụ thuộc B.
+// Cạnh tới service không có trong đồ thị bị bỏ qua
+type Graph struct {
+	services   map[string]*model.Service
+	order      []string            // id theo thứ tự đưa vào, giữ output ổn định
+	dependsOn  map[string][]string // id -> các service nó phụ thuộc
+	dependents map[string][]string // id -> các service phụ thuộc nó
+}
+
+// Impact service bị ảnh hưởng gián tiếp, Path đi từ service gốc tới service này
+type Impact struct {
+	Service *model.Service
+	Depth   int
+	Path    []string
+}
+
+func NewGraph(services []*model.Service) *Graph {
+	g := &Graph{
+		services:   make(map[string]*model.Service, len(services)),
+		order:      make([]string, 0, len(services)),
+		dependsOn:  make(map[string][]string, len(services)),
+		dependents: make(map[string][]string, len(services)),
+	}
+	for _, svc := range services {
+		id := svc.ID.Hex()
+		if _, ok := g.services[id]; !ok {
+			g.order = append(g.order, id)
+		}
+		g.services[id] = svc
+	}
+	for _, id := range g.order {
+		for _, dep := range g.services[id].DependsOn {
+			if _, ok := g.services[dep]; ok && dep != id {
+				g.dependsOn[id] = append(g.dependsOn[id], dep)
+				g.dependents[dep] = append(g.dependents[dep], id)
+			}
+		}
+	}
+	return g
+}
+
+func (g *Graph) Service(id string) (*model.Service, bool) {
+	svc, ok := g.services[id]
+	return svc, ok
+}
+
+// Cycle kiểm tra việc đặt dependsOn cho id có tạo vòng không,
+// có thì trả về vòng dạng [id, ..., id], không thì nil
+func (g *Graph) Cycle(id string, dependsOn []string) []string {
+	for _, dep := range dependsOn {
+		if dep == id {
+			return []string{id, id}
+		}
+		if path := g.pathTo(dep, id, map[string]bool{}); path != nil {
+			return append([]string{id}, path...)
+		}
+	}
+	return nil
+}
+
+// pathTo tìm đường theo cạnh phụ thuộc từ from tới to (DFS), cạnh hiện có của to không được tính
+// vì chúng sẽ bị thay bởi dependsOn mới
+func (g *Graph) pathTo(from, to string, visited map[string]bool) []string {
+	if from == to {
+		return []string{to}
+	}
+	if visited[from] {
+		return nil
+	}
+	visited[from] = true
+	for _, next := range g.dependsOn[from] {
+		if path := g.pathTo(next, to, visited); path != nil {
+			return append([]string{from}, path...)
+		}
+	}
+	return nil
+}
+
+// Impacted các service phụ thuộc trực tiếp hoặc gián tiếp vào một trong ids (BFS theo chiều ngược),
+// mỗi service chỉ xuất hiện một lần với đường ngắn nhất, sắp theo độ sâu rồi title
+func (g *Graph) Impacted(ids ...string) []Impact {
+	paths := make(map[string][]string)
+	queue := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := g.services[id]; ok {
+			if _, seen := paths[id]; !seen {
+				paths[id] = []string{id}
+				queue = append(queue, id)
+			}
+		}
+	}
+
+	var result []Impact
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range g.dependents[current] {
+			if _, seen := paths[dependent]; seen {
+				continue
+			}
+			path := append(append([]string{}, paths[current]...), dependent)
+			paths[dependent] = path
+			queue = append(queue, dependent)
+			result = append(result, Impact{Service: g.services[dependent], Depth: len(path) - 1, Path: path})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Depth != result[j].Depth {
+			return result[i].Depth < result[j].Depth
+		}
+		return result[i].Service.Title < result[j].Service.Title
+	})
+	return result
+}
+
+// Edges mọi cạnh [từ, tới] theo thứ tự service
+func (g *Graph) Edges() [][2]string {
+	var edges [][2]string
+	for _, id := range g.order {
+		for _, dep := range g.dependsOn[id] {
+			edges = append(edges, [2]string{id, dep})
+		}
+	}
+	return edges
+}
+
+// Services theo thứ tự đưa vào
+func (g *Graph) Services() []*model.Service {
+	result := make([]*model.Service, 0, len(g.order))
+	for _, id := range g.order {
+		result = append(result, g.services[id])
+	}
+	return result
+}
+
+// Màu node trong DOT
+const (
+	colorDown     = "#f8d7da"
+	colorImpacted = "#fff3cd"
+	colorInactive = "#e2e3e5"
+	colorDefault  = "#ffffff"
+)
+
+// DOT xuất đồ thị theo định dạng Graphviz, service down tô đỏ, service bị ảnh hưởng tô vàng
+func (g *Graph) DOT() string {
+	var down []string
+	for _, id := range g.order {
+		if isDown(g.services[id]) {
+			down = append(down, id)
+		}
+	}
+	impacted := make(map[string]bool)
+	for _, impact := range g.Impacted(down...) {
+		impacted[impact.Service.ID.Hex()] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph services {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"" + colorDefault + "\"];\n")
+	for _, id := range g.order {
+		svc := g.services[id]
+		color := colorDefault
+		switch {
+		case isDown(svc):
+			color = colorDown
+		case impacted[id]:
+			color = colorImpacted
+		case svc.Status != "" && svc.Status != string(constants.ServiceStatusActive):
+			color = colorInactive
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, fillcolor=%q];\n", quote(id), quote(svc.Title), color)
+	}
+	for _, edge := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s;\n", quote(edge[0]), quote(edge[1]))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func isDown(svc *model.Service) bool {
+	return svc.Health != nil && svc.Health.Status == model.HealthStatusDown
+}
+
+// quote chuỗi DOT: chỉ cần escape \ và "
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
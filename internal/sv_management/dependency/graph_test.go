@@ -0,0 +1,162 @@
+package dependency
+
+import (
+	"fmt"
+	"services-management/internal/sv_management/model"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testGraph dựng đồ thị từ danh sách "tên: phụ thuộc,..." theo thứ tự, title là tên service.
+// Trả về đồ thị và hàm đổi id sang tên để so sánh path dễ đọc
+func testGraph(t *testing.T, specs ...string) (*Graph, map[string]string, func([]string) string) {
+	t.Helper()
+	ids := make(map[string]string)
+	names := make(map[string]string)
+	idOf := func(name string) string {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		id := primitive.NewObjectID().Hex()
+		ids[name], names[id] = id, name
+		return id
+	}
+
+	var services []*model.Service
+	for _, spec := range specs {
+		name, deps, _ := strings.Cut(spec, ":")
+		id, err := primitive.ObjectIDFromHex(idOf(strings.TrimSpace(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		svc := &model.Service{ID: id, Title: strings.TrimSpace(name)}
+		for _, dep := range strings.Split(deps, ",") {
+			if dep = strings.TrimSpace(dep); dep != "" {
+				svc.DependsOn = append(svc.DependsOn, idOf(dep))
+			}
+		}
+		services = append(services, svc)
+	}
+
+	toNames := func(path []string) string {
+		named := make([]string, 0, len(path))
+		for _, id := range path {
+			named = append(named, names[id])
+		}
+		return strings.Join(named, " -> ")
+	}
+	return NewGraph(services), ids, toNames
+}
+
+func TestCycle(t *testing.T) {
+	tests := []struct {
+		name      string
+		graph     []string
+		id        string
+		dependsOn []string
+		cycle     string // rỗng là không có vòng
+	}{
+		{name: "không phụ thuộc", graph: []string{"a:", "b:"}, id: "a"},
+		{name: "phụ thuộc một chiều", graph: []string{"a:", "b:", "c: b"}, id: "a", dependsOn: []string{"b", "c"}},
+		{name: "tự phụ thuộc", graph: []string{"a:"}, id: "a", dependsOn: []string{"a"}, cycle: "a -> a"},
+		{name: "vòng hai service", graph: []string{"a:", "b: a"}, id: "a", dependsOn: []string{"b"}, cycle: "a -> b -> a"},
+		{name: "vòng gián tiếp", graph: []string{"a:", "b: c", "c: a"}, id: "a", dependsOn: []string{"b"}, cycle: "a -> b -> c -> a"},
+		{name: "vòng qua phụ thuộc thứ hai", graph: []string{"a:", "b:", "c: a"}, id: "a", dependsOn: []string{"b", "c"}, cycle: "a -> c -> a"},
+		{name: "vòng qua cạnh có sẵn", graph: []string{"a: b", "b:"}, id: "b", dependsOn: []string{"a"}, cycle: "b -> a -> b"},
+		{name: "bỏ cạnh cũ để gỡ vòng", graph: []string{"a: b", "b:"}, id: "a", dependsOn: []string{}},
+		{name: "kim cương không phải vòng", graph: []string{"a:", "b: d", "c: d", "d:"}, id: "a", dependsOn: []string{"b", "c"}},
+		{name: "phụ thuộc service ngoài đồ thị", graph: []string{"a:"}, id: "a", dependsOn: []string{"x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, ids, toNames := testGraph(t, tt.graph...)
+			dependsOn := make([]string, 0, len(tt.dependsOn))
+			for _, name := range tt.dependsOn {
+				id, ok := ids[name]
+				if !ok {
+					id = primitive.NewObjectID().Hex()
+				}
+				dependsOn = append(dependsOn, id)
+			}
+
+			if got := toNames(g.Cycle(ids[tt.id], dependsOn)); got != tt.cycle {
+				t.Fatalf("Cycle = %q, want %q", got, tt.cycle)
+			}
+		})
+	}
+}
+
+func TestImpacted(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    []string
+		down     []string
+		impacted []string // "tên (độ sâu): path"
+	}{
+		{name: "không ai phụ thuộc", graph: []string{"a:", "b:"}, down: []string{"a"}, impacted: nil},
+		{
+			name:     "trực tiếp và gián tiếp",
+			graph:    []string{"db:", "api: db", "web: api", "admin: api"},
+			down:     []string{"db"},
+			impacted: []string{"api (1): db -> api", "admin (2): db -> api -> admin", "web (2): db -> api -> web"},
+		},
+		{
+			name:     "giữ đường ngắn nhất",
+			graph:    []string{"db:", "api: db", "web: api, db"},
+			down:     []string{"db"},
+			impacted: []string{"api (1): db -> api", "web (1): db -> web"},
+		},
+		{
+			name:     "nhiều service down, không lặp",
+			graph:    []string{"db:", "cache:", "api: db, cache"},
+			down:     []string{"db", "cache"},
+			impacted: []string{"api (1): db -> api"},
+		},
+		{
+			name:     "service down phụ thuộc nhau không bị tính là ảnh hưởng",
+			graph:    []string{"db:", "api: db"},
+			down:     []string{"db", "api"},
+			impacted: nil,
+		},
+		{
+			name:     "vòng có sẵn không lặp vô hạn",
+			graph:    []string{"a: b", "b: a", "c: b"},
+			down:     []string{"a"},
+			impacted: []string{"b (1): a -> b", "c (2): a -> b -> c"},
+		},
+		{name: "service không có trong đồ thị", graph: []string{"a:"}, down: []string{"x"}, impacted: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, ids, toNames := testGraph(t, tt.graph...)
+			down := make([]string, 0, len(tt.down))
+			for _, name := range tt.down {
+				id, ok := ids[name]
+				if !ok {
+					id = primitive.NewObjectID().Hex()
+				}
+				down = append(down, id)
+			}
+
+			var got []string
+			for _, impact := range g.Impacted(down...) {
+				got = append(got, fmt.Sprintf("%s (%d): %s", impact.Service.Title, impact.Depth, toNames(impact.Path)))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.impacted) {
+				t.Fatalf("Impacted = %v, want %v", got, tt.impacted)
+			}
+		})
+	}
+}
+
+func TestNewGraphSkipsUnknownAndSelfEdges(t *testing.T) {
+	g, ids, _ := testGraph(t, "a: a, b, x", "b:")
+	edges := g.Edges()
+	if len(edges) != 1 || edges[0] != [2]string{ids["a"], ids["b"]} {
+		t.Fatalf("edges = %v, want only a -> b", edges)
+	}
+}
@@ -0,0 +1,16 @@
+package request
+
+// UsageReportRequest khoảng ngày theo UTC, mặc định 30 ngày gần nhất
+type UsageReportRequest struct {
+	From           string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To             string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	OrganizationID string `form:"organization_id"`
+	Role           string `form:"role"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Format         string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+type DailyActiveUsersRequest struct {
+	UsageReportRequest
+	ServiceID string `form:"service_id" binding:"required"`
+}
@@ -0,0 +1,22 @@
+package request
+
+import "time"
+
+type AnnouncementRequest struct {
+	TargetType    string     `json:"target_type" binding:"required,oneof=service group"`
+	TargetID      string     `json:"target_id" binding:"required"`
+	Kind          string     `json:"kind" binding:"required,oneof=maintenance announcement"`
+	Severity      string     `json:"severity" binding:"omitempty,oneof=info warning critical"`
+	Message       string     `json:"message" binding:"required,max=1000"`
+	StartsAt      *time.Time `json:"starts_at"` // bỏ trống là bắt đầu ngay
+	EndsAt        *time.Time `json:"ends_at"`   // bắt buộc với maintenance
+	DisableLaunch bool       `json:"disable_launch"`
+}
+
+type ListAnnouncementsRequest struct {
+	TargetType string `form:"target_type" binding:"omitempty,oneof=service group"`
+	TargetID   string `form:"target_id"`
+	Kind       string `form:"kind" binding:"omitempty,oneof=maintenance announcement"`
+	// Current chỉ lấy announcement đang hoặc sắp hiệu lực
+	Current bool `form:"current"`
+}
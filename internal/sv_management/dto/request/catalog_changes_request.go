@@ -0,0 +1,11 @@
+package request
+
+// CatalogChangesRequest organization lấy theo organization đang dùng của user, không nhận từ query
+type CatalogChangesRequest struct {
+	Since int64  `form:"since" binding:"min=0"` // next_cursor của lần sync trước, 0 là sync toàn bộ
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Lang  string `form:"lang"`
+
+	// Locales do handler resolve từ lang hoặc Accept-Language
+	Locales []string `form:"-"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type SetDependenciesRequest struct {
+	DependsOn []string `json:"depends_on" binding:"max=50,dive,required"`
+}
+
+type DependencyGraphRequest struct {
+	OrganizationID string `form:"organization_id"`
+	Format         string `form:"format" binding:"omitempty,oneof=json dot"`
+}
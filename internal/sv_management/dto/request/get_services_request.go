@@ -0,0 +1,25 @@
+package request
+
+type GetServicesRequest struct {
+	Page           int    `form:"page" binding:"omitempty,min=1"`
+	Size           int    `form:"size" binding:"omitempty,min=1"`
+	Search         string `form:"search"`
+	GroupID        string `form:"group_id"`
+	OrganizationID string `form:"organization_id"`
+	Tag            string `form:"tag"`
+	Status         string `form:"status" binding:"omitempty,oneof=active inactive"`
+	Role           string `form:"role"`
+	SortBy         string `form:"sort_by" binding:"omitempty,oneof=title order created_at updated_at"`
+	SortOrder      string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	Lang           string `form:"lang"`
+	Shape          string `form:"shape" binding:"omitempty,oneof=flat tree"`
+
+	// Locales do handler resolve từ lang hoặc Accept-Language
+	Locales []string `form:"-"`
+}
+
+// IsLegacy không có tham số phân trang, lọc, sắp xếp hay shape: client cũ nhận mảng toàn bộ group
+func (r GetServicesRequest) IsLegacy() bool {
+	return r.Page == 0 && r.Size == 0 && r.Search == "" && r.GroupID == "" && r.OrganizationID == "" &&
+		r.Tag == "" && r.Status == "" && r.Role == "" && r.SortBy == "" && r.SortOrder == "" && r.Shape == ""
+}
@@ -0,0 +1,25 @@
+package request
+
+type HealthCheckConfigRequest struct {
+	Disabled       bool   `json:"disabled"`
+	Method         string `json:"method" binding:"omitempty,oneof=GET HEAD"`
+	ProbeUrl       string `json:"probe_url" binding:"omitempty,url"`
+	TimeoutMs      int    `json:"timeout_ms" binding:"omitempty,min=100,max=60000"`
+	ExpectedStatus int    `json:"expected_status" binding:"omitempty,min=100,max=599"`
+	Keyword        string `json:"keyword" binding:"max=200"`
+}
+
+type FailingServicesRequest struct {
+	OrganizationID string `form:"organization_id"`
+	Format         string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// MarkHealthStatusRequest admin đặt trạng thái tay, lượt probe sau sẽ ghi đè nếu probe còn bật
+type MarkHealthStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=up down"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type HealthHistoryRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
@@ -0,0 +1,21 @@
+package request
+
+type OwnershipRequest struct {
+	OwnerTeam    string `json:"owner_team" binding:"required,max=100"`
+	OwnerStaffID string `json:"owner_staff_id" binding:"max=64"`
+	ContactEmail string `json:"contact_email" binding:"omitempty,email"`
+	ContactPhone string `json:"contact_phone" binding:"omitempty,max=32"`
+	SupportUrl   string `json:"support_url" binding:"omitempty,url"`
+	SLATier      string `json:"sla_tier" binding:"omitempty,oneof=gold silver bronze"`
+	DocsUrl      string `json:"docs_url" binding:"omitempty,url"`
+}
+
+// OwnerServicesRequest owner_team/owner_staff_id rỗng thì lấy mọi owner
+type OwnerServicesRequest struct {
+	OwnerTeam      string `form:"owner_team"`
+	OwnerStaffID   string `form:"owner_staff_id"`
+	OrganizationID string `form:"organization_id"`
+	Lang           string `form:"lang"`
+	// Locales do handler resolve từ lang hoặc Accept-Language
+	Locales []string `form:"-"`
+}
@@ -0,0 +1,11 @@
+package request
+
+type CatalogViewRequest struct {
+	OrganizationID string `form:"organization_id" binding:"required"`
+	AggregateType  string `form:"aggregate_type" binding:"omitempty,oneof=service group"`
+}
+
+type StreamHistoryRequest struct {
+	From  uint64 `form:"from"` // version bắt đầu trong stream
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
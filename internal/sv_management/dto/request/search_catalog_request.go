@@ -0,0 +1,7 @@
+package request
+
+// SearchCatalogRequest user search catalog, organization và role lấy theo user hiện tại
+type SearchCatalogRequest struct {
+	Query string `form:"q" binding:"required"`
+	Size  int    `form:"size" binding:"omitempty,min=1"`
+}
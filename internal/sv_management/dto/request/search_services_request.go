@@ -0,0 +1,9 @@
+package request
+
+type SearchServicesRequest struct {
+	Query          string `form:"q" binding:"required"`
+	OrganizationID string `form:"organization_id"`
+	Role           string `form:"role"`
+	Status         string `form:"status" binding:"omitempty,oneof=active inactive"`
+	Size           int    `form:"size" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,39 @@
+package request
+
+type SmartCollectionRequest struct {
+	Title          string                        `json:"title" binding:"required,max=100"`
+	OrganizationID string                        `json:"organization_id"` // rỗng là mọi organization
+	Order          int                           `json:"order"`
+	Limit          int                           `json:"limit" binding:"min=0,max=100"`
+	Query          CollectionQueryRequest        `json:"query"`
+	Translations   map[string]TranslationRequest `json:"translations" binding:"omitempty,dive"`
+}
+
+// CollectionQueryRequest cần ít nhất một điều kiện
+type CollectionQueryRequest struct {
+	Tags            []string `json:"tags" binding:"max=20,dive,required,max=32"`
+	MatchAllTags    bool     `json:"match_all_tags"`
+	Roles           []string `json:"roles" binding:"dive,required"`
+	OrganizationIDs []string `json:"organization_ids" binding:"dive,required"`
+}
+
+type ListSmartCollectionsRequest struct {
+	OrganizationID string `form:"organization_id"`
+}
+
+type CollectionServicesRequest struct {
+	Lang    string   `form:"lang"`
+	Locales []string `form:"-"`
+}
+
+type SetServiceTagsRequest struct {
+	Tags []string `json:"tags" binding:"max=20,dive,required,max=32"`
+}
+
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required,max=32"`
+}
+
+type ListTagsRequest struct {
+	OrganizationID string `form:"organization_id"`
+}
@@ -0,0 +1,16 @@
+package request
+
+// SSOConfigRequest secret/private_key bỏ trống thì hệ thống tự sinh
+type SSOConfigRequest struct {
+	Algorithm    string            `json:"algorithm" binding:"required,oneof=HS256 RS256"`
+	Secret       string            `json:"secret" binding:"omitempty,min=32"`
+	PrivateKey   string            `json:"private_key"`
+	Audience     string            `json:"audience" binding:"max=255"`
+	ClaimMapping map[string]string `json:"claim_mapping"`
+	TTLSeconds   int               `json:"ttl_seconds" binding:"omitempty,min=10,max=600"`
+	Param        string            `json:"param" binding:"max=64"`
+}
+
+type VerifySSOTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// StatusPageRequest status page công khai nên bắt buộc chọn một organization
+type StatusPageRequest struct {
+	OrganizationID string `form:"organization_id" binding:"required"`
+	Lang           string `form:"lang"`
+
+	// Locales do handler resolve từ lang hoặc Accept-Language
+	Locales []string `form:"-"`
+}
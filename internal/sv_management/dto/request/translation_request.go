@@ -0,0 +1,6 @@
+package request
+
+type TranslationRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description" binding:"max=500"`
+}
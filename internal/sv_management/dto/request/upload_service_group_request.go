@@ -1,6 +1,21 @@
 package request
 
 type UploadServiceGroupRequest struct {
-	Title string `json:"title" binding:"required"`
-	Order int    `json:"order" binding:"required"`
+	Title          string                        `json:"title" binding:"required"`
+	Order          int                           `json:"order" binding:"required"`
+	OrganizationID string                        `json:"organization_id"`
+	ParentID       string                        `json:"parent_id"`
+	Icon           *IconRequest                  `json:"icon"`
+	Translations   map[string]TranslationRequest `json:"translations" binding:"omitempty,dive"`
+}
+
+type MoveServiceGroupRequest struct {
+	ParentID string `json:"parent_id"`
+	Order    int    `json:"order" binding:"required"`
+}
+
+// ReorderServiceGroupsRequest sắp xếp lại các group con của ParentID theo thứ tự GroupIDs
+type ReorderServiceGroupsRequest struct {
+	ParentID string   `json:"parent_id"`
+	GroupIDs []string `json:"group_ids" binding:"required,min=1"`
 }
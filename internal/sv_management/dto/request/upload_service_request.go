@@ -1,8 +1,26 @@
 package request
 
 type UploadServiceRequest struct {
-	Title   string `json:"service_name" binding:"required"`
-	Url     string `json:"url" binding:"required"`
-	Order   int    `json:"order" binding:"required"`
-	GroupID string `json:"group_id" binding:"required"`
+	Title          string                        `json:"service_name" binding:"required"`
+	Url            string                        `json:"url" binding:"required"`
+	Urls           map[string]string             `json:"urls" binding:"omitempty,dive,keys,required,max=32,endkeys,required"`
+	Order          int                           `json:"order" binding:"required"`
+	GroupID        string                        `json:"group_id" binding:"required"`
+	OrganizationID string                        `json:"organization_id"`
+	Description    string                        `json:"description" binding:"max=500"`
+	Icon           *IconRequest                  `json:"icon"`
+	Color          string                        `json:"color" binding:"omitempty,hexcolor"`
+	LaunchMode     string                        `json:"launch_mode" binding:"omitempty,oneof=new_tab embedded native_app"`
+	Tags           []string                      `json:"tags" binding:"max=20,dive,required,max=32"`
+	Status         string                        `json:"status" binding:"omitempty,oneof=active inactive"`
+	Roles          []string                      `json:"roles"`
+	Translations   map[string]TranslationRequest `json:"translations" binding:"omitempty,dive"`
+	Ownership      *OwnershipRequest             `json:"ownership"`
+}
+
+// IconRequest cần image_key (ảnh đã upload) hoặc image_url
+type IconRequest struct {
+	ImageID  uint64 `json:"image_id"`
+	ImageKey string `json:"image_key" binding:"required_without=ImageUrl"`
+	ImageUrl string `json:"image_url" binding:"omitempty,url"`
 }
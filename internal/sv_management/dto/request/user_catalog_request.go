@@ -0,0 +1,25 @@
+package request
+
+// GetCatalogRequest organization lấy theo organization đang dùng của user, không nhận từ query
+type GetCatalogRequest struct {
+	Lang string `form:"lang"`
+
+	// Locales do handler resolve từ lang hoặc Accept-Language
+	Locales []string `form:"-"`
+}
+
+type ReorderFavoritesRequest struct {
+	ServiceIDs []string `json:"service_ids" binding:"required"`
+}
+
+// SetOrderingRequest thứ tự riêng của user, id nằm trước thì hiển thị trước
+type SetOrderingRequest struct {
+	GroupIDs   []string `json:"group_ids"`
+	ServiceIDs []string `json:"service_ids"`
+}
+
+// CatalogStreamRequest last_event_id dùng khi client không gửi được header Last-Event-ID.
+// Organization lấy theo organization đang dùng của user, không nhận từ query
+type CatalogStreamRequest struct {
+	LastEventID string `form:"last_event_id"`
+}
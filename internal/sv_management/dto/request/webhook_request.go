@@ -0,0 +1,16 @@
+package request
+
+// WebhookRequest secret bỏ trống thì hệ thống tự sinh khi tạo, giữ nguyên khi cập nhật
+type WebhookRequest struct {
+	Url         string   `json:"url" binding:"required,url,max=2048"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=256"`
+	Events      []string `json:"events"` // rỗng là mọi event, hỗ trợ "*" và "service.*"
+	Active      *bool    `json:"active"` // mặc định true
+	Description string   `json:"description" binding:"max=500"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	Page   int    `form:"page"`
+	Size   int    `form:"size"`
+}
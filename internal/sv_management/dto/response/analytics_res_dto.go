@@ -0,0 +1,44 @@
+package response
+
+type ServiceUsageResDto struct {
+	ServiceID   string `json:"service_id"`
+	Title       string `json:"title"`
+	Clicks      int64  `json:"clicks"`
+	UniqueUsers int64  `json:"unique_users"`
+}
+
+type DailyUsageResDto struct {
+	Day         string `json:"day"`
+	Clicks      int64  `json:"clicks"`
+	ActiveUsers int64  `json:"active_users"`
+}
+
+type ServiceTrendResDto struct {
+	ServiceID      string   `json:"service_id"`
+	Title          string   `json:"title"`
+	Clicks         int64    `json:"clicks"`
+	PreviousClicks int64    `json:"previous_clicks"`
+	Change         int64    `json:"change"`
+	ChangePercent  *float64 `json:"change_percent"` // null khi kỳ trước không có click
+}
+
+type ServiceUsageReportResponse struct {
+	From  string                `json:"from"`
+	To    string                `json:"to"`
+	Items []*ServiceUsageResDto `json:"items"`
+}
+
+type DailyUsageReportResponse struct {
+	ServiceID string              `json:"service_id"`
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Items     []*DailyUsageResDto `json:"items"`
+}
+
+type ServiceTrendReportResponse struct {
+	From         string                `json:"from"`
+	To           string                `json:"to"`
+	PreviousFrom string                `json:"previous_from"`
+	PreviousTo   string                `json:"previous_to"`
+	Items        []*ServiceTrendResDto `json:"items"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type AnnouncementResDto struct {
+	ID            string     `json:"id"`
+	TargetType    string     `json:"target_type"`
+	TargetID      string     `json:"target_id"`
+	Kind          string     `json:"kind"`
+	Severity      string     `json:"severity"`
+	Message       string     `json:"message"`
+	StartsAt      time.Time  `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	DisableLaunch bool       `json:"disable_launch"`
+	Active        bool       `json:"active"`
+}
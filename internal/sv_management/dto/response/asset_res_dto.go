@@ -0,0 +1,19 @@
+package response
+
+type AssetResDto struct {
+	ID          string               `json:"id"`
+	Url         string               `json:"url"`
+	FileName    string               `json:"file_name"`
+	ContentType string               `json:"content_type"`
+	Size        int64                `json:"size"`
+	Width       int                  `json:"width"`
+	Height      int                  `json:"height"`
+	Variants    []AssetVariantResDto `json:"variants"`
+}
+
+type AssetVariantResDto struct {
+	Size   int    `json:"size"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Url    string `json:"url"`
+}
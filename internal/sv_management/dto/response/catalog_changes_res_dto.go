@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+// CatalogChangeResDto Deleted là tombstone: client xoá entity khỏi bản lưu cục bộ
+// (entity bị xoá, bị tắt hoặc user không còn được thấy)
+type CatalogChangeResDto struct {
+	Type      string                `json:"type"` // service | group
+	ID        string                `json:"id"`
+	Seq       int64                 `json:"seq"`
+	Deleted   bool                  `json:"deleted"`
+	ChangedAt time.Time             `json:"changed_at"`
+	Service   *ServiceResDto        `json:"service,omitempty"`
+	Group     *ServiceGroupResponse `json:"group,omitempty"`
+}
+
+type CatalogChangesResponse struct {
+	Changes []CatalogChangeResDto `json:"changes"`
+	// NextCursor truyền vào since ở lần sync sau
+	NextCursor int64 `json:"next_cursor"`
+	HasMore    bool  `json:"has_more"`
+	// Reset cursor không hợp lệ với server này, client xoá bản lưu và sync lại từ 0
+	Reset bool `json:"reset"`
+}
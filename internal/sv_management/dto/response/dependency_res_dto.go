@@ -0,0 +1,42 @@
+package response
+
+type DependenciesResDto struct {
+	ServiceID string   `json:"service_id"`
+	DependsOn []string `json:"depends_on"`
+}
+
+type DependencyGraphResDto struct {
+	Nodes []DependencyNodeResDto `json:"nodes"`
+	Edges []DependencyEdgeResDto `json:"edges"`
+}
+
+type DependencyNodeResDto struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	OrganizationID string `json:"organization_id"`
+	Status         string `json:"status"`
+	HealthStatus   string `json:"health_status,omitempty"`
+	// Impacted phụ thuộc (gián tiếp) vào một service đang down
+	Impacted bool `json:"impacted"`
+}
+
+// DependencyEdgeResDto From phụ thuộc To
+type DependencyEdgeResDto struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type ImpactResDto struct {
+	ServiceID    string                  `json:"service_id"`
+	Title        string                  `json:"title"`
+	HealthStatus string                  `json:"health_status,omitempty"`
+	Impacted     []ImpactedServiceResDto `json:"impacted"`
+}
+
+// ImpactedServiceResDto Path là id các service từ service gốc tới service này
+type ImpactedServiceResDto struct {
+	ServiceID string   `json:"service_id"`
+	Title     string   `json:"title"`
+	Depth     int      `json:"depth"`
+	Path      []string `json:"path"`
+}
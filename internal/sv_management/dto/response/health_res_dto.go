@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+type HealthCheckResDto struct {
+	Url        string    `json:"url"`
+	Status     string    `json:"status"`
+	StatusCode int       `json:"status_code"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+type FailingServiceResDto struct {
+	ServiceID      string    `json:"service_id"`
+	Title          string    `json:"title"`
+	Url            string    `json:"url"`
+	OrganizationID string    `json:"organization_id"`
+	StatusCode     int       `json:"status_code"`
+	Error          string    `json:"error"`
+	LastChecked    time.Time `json:"last_checked"`
+	FailingSince   time.Time `json:"failing_since"`
+	// ImpactedServices service phụ thuộc trực tiếp hoặc gián tiếp vào service này
+	ImpactedServices []ImpactedServiceResDto `json:"impacted_services"`
+}
+
+type HealthCheckConfigResDto struct {
+	ServiceID      string `json:"service_id"`
+	Disabled       bool   `json:"disabled"`
+	Method         string `json:"method,omitempty"`
+	ProbeUrl       string `json:"probe_url,omitempty"`
+	TimeoutMs      int    `json:"timeout_ms,omitempty"`
+	ExpectedStatus int    `json:"expected_status,omitempty"`
+	Keyword        string `json:"keyword,omitempty"`
+}
@@ -0,0 +1,7 @@
+package response
+
+type IconResDto struct {
+	ImageID  uint64 `json:"image_id"`
+	ImageKey string `json:"image_key"`
+	ImageUrl string `json:"image_url"`
+}
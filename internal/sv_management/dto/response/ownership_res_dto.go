@@ -0,0 +1,33 @@
+package response
+
+type OwnershipResDto struct {
+	OwnerTeam    string `json:"owner_team"`
+	OwnerStaffID string `json:"owner_staff_id,omitempty"`
+	// Owner thông tin nhân sự phụ trách, chỉ có ở API hỗ trợ/owner và khi user service trả được
+	Owner        *StaffContactResDto `json:"owner,omitempty"`
+	ContactEmail string              `json:"contact_email,omitempty"`
+	ContactPhone string              `json:"contact_phone,omitempty"`
+	SupportUrl   string              `json:"support_url,omitempty"`
+	SLATier      string              `json:"sla_tier,omitempty"`
+	DocsUrl      string              `json:"docs_url,omitempty"`
+}
+
+type StaffContactResDto struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarUrl string `json:"avatar_url,omitempty"`
+}
+
+// OwnerServicesResDto service của một owner, owner_team rỗng là các service chưa có owner
+type OwnerServicesResDto struct {
+	OwnerTeam    string              `json:"owner_team"`
+	OwnerStaffID string              `json:"owner_staff_id,omitempty"`
+	Owner        *StaffContactResDto `json:"owner,omitempty"`
+	Services     []ServiceResDto     `json:"services"`
+}
+
+type ServiceSupportResDto struct {
+	ServiceID string           `json:"service_id"`
+	Title     string           `json:"title"`
+	Ownership *OwnershipResDto `json:"ownership"`
+}
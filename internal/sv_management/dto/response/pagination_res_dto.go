@@ -0,0 +1,24 @@
+package response
+
+type PaginationResponse struct {
+	Page  int   `json:"page"`
+	Size  int   `json:"size"`
+	Total int64 `json:"total"`
+}
+
+type ServicesPageResponse struct {
+	Items         []*ServicesResponse `json:"items"`
+	TotalServices int64               `json:"total_services"`
+	Pagination    PaginationResponse  `json:"pagination"`
+}
+
+type ServicesTreePageResponse struct {
+	Items         []*ServicesTreeResponse `json:"items"`
+	TotalServices int64                   `json:"total_services"`
+	Pagination    PaginationResponse      `json:"pagination"`
+}
+
+type ServiceListResponse struct {
+	Items      []*ServiceResDto   `json:"items"`
+	Pagination PaginationResponse `json:"pagination"`
+}
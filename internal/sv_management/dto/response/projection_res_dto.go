@@ -0,0 +1,22 @@
+package response
+
+import (
+	"services-management/internal/sv_management/eventstore"
+	"time"
+)
+
+type ProjectionStatusResDto struct {
+	Name            string               `json:"name"`
+	Started         bool                 `json:"started"`
+	Position        *eventstore.Position `json:"position,omitempty"` // nil khi chưa áp dụng event nào
+	Processed       int64                `json:"processed"`
+	ReplayRequested bool                 `json:"replay_requested"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
+
+type StreamHistoryResDto struct {
+	Stream string              `json:"stream"`
+	Events []eventstore.Record `json:"events"`
+	// Next version để đọc trang tiếp theo, nil khi đã hết
+	Next *uint64 `json:"next,omitempty"`
+}
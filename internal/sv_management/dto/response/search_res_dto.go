@@ -0,0 +1,14 @@
+package response
+
+type SearchServiceResDto struct {
+	Service    *ServiceResDto      `json:"service"`
+	GroupID    string              `json:"group_id"`
+	GroupTitle string              `json:"group_title"`
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+type SearchServicesResponse struct {
+	Query string                 `json:"query"`
+	Items []*SearchServiceResDto `json:"items"`
+}
@@ -1,8 +1,27 @@
 package response
 
+import "time"
+
 type ServiceResDto struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
-	Order int    `json:"order"`
-	Url   string `json:"url"`
+	ID             string                       `json:"id"`
+	OrganizationID string                       `json:"organization_id"`
+	Title          string                       `json:"title"`
+	Order          int                          `json:"order"`
+	Url            string                       `json:"url"`
+	Urls           map[string]string            `json:"urls,omitempty"`
+	Description    string                       `json:"description"`
+	Icon           *IconResDto                  `json:"icon"`
+	Color          string                       `json:"color"`
+	LaunchMode     string                       `json:"launch_mode"`
+	Tags           []string                     `json:"tags"`
+	Status         string                       `json:"status"`
+	Roles          []string                     `json:"roles"`
+	Translations   map[string]TranslationResDto `json:"translations,omitempty"`
+	IsFavorite     bool                         `json:"is_favorite,omitempty"`
+	HealthStatus   string                       `json:"health_status,omitempty"`
+	LastChecked    *time.Time                   `json:"last_checked,omitempty"`
+	Announcements  []AnnouncementResDto         `json:"announcements,omitempty"`
+	LaunchDisabled bool                         `json:"launch_disabled,omitempty"`
+	Ownership      *OwnershipResDto             `json:"ownership,omitempty"`
+	DependsOn      []string                     `json:"depends_on,omitempty"`
 }
@@ -6,7 +6,19 @@ type ServicesResponse struct {
 }
 
 type ServiceGroupResponse struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
-	Order int    `json:"order"`
+	ID       string      `json:"id"`
+	ParentID string      `json:"parent_id,omitempty"`
+	Title    string      `json:"title"`
+	Order    int         `json:"order"`
+	Icon     *IconResDto `json:"icon"`
+	// Kind "collection" là smart collection tính động, rỗng là group thường
+	Kind string `json:"kind,omitempty"`
+
+	Announcements []AnnouncementResDto `json:"announcements,omitempty"`
+}
+
+type ServicesTreeResponse struct {
+	Group    ServiceGroupResponse    `json:"group"`
+	Services []ServiceResDto         `json:"services"`
+	Children []*ServicesTreeResponse `json:"children"`
 }
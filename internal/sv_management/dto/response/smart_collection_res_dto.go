@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type SmartCollectionResDto struct {
+	ID             string                       `json:"id"`
+	OrganizationID string                       `json:"organization_id"`
+	Title          string                       `json:"title"`
+	Order          int                          `json:"order"`
+	Limit          int                          `json:"limit"`
+	Query          CollectionQueryResDto        `json:"query"`
+	Translations   map[string]TranslationResDto `json:"translations,omitempty"`
+	CreatedBy      string                       `json:"created_by"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	UpdatedAt      time.Time                    `json:"updated_at"`
+}
+
+type CollectionQueryResDto struct {
+	Tags            []string `json:"tags"`
+	MatchAllTags    bool     `json:"match_all_tags"`
+	Roles           []string `json:"roles"`
+	OrganizationIDs []string `json:"organization_ids"`
+}
+
+type TagCountResDto struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
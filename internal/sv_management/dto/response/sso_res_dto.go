@@ -0,0 +1,19 @@
+package response
+
+type SSOConfigResDto struct {
+	ServiceID    string            `json:"service_id"`
+	Enabled      bool              `json:"enabled"`
+	Algorithm    string            `json:"algorithm"`
+	Audience     string            `json:"audience"`
+	ClaimMapping map[string]string `json:"claim_mapping"`
+	TTLSeconds   int               `json:"ttl_seconds"`
+	Param        string            `json:"param"`
+	PublicKey    string            `json:"public_key,omitempty"`
+	// Secret chỉ trả về một lần khi hệ thống tự sinh secret HS256
+	Secret string `json:"secret,omitempty"`
+}
+
+type VerifySSOTokenResponse struct {
+	ServiceID string         `json:"service_id"`
+	Claims    map[string]any `json:"claims"`
+}
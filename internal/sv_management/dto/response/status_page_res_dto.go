@@ -0,0 +1,42 @@
+package response
+
+import "time"
+
+type UptimeResDto struct {
+	Last24h *float64 `json:"last_24h"`
+	Last7d  *float64 `json:"last_7d"`
+	Last30d *float64 `json:"last_30d"`
+}
+
+type ResponseTimeResDto struct {
+	P50 int64 `json:"p50_ms"`
+	P95 int64 `json:"p95_ms"`
+	P99 int64 `json:"p99_ms"`
+}
+
+type IncidentResDto struct {
+	Start           time.Time  `json:"start"`
+	End             *time.Time `json:"end"` // null là sự cố đang diễn ra
+	DurationSeconds int64      `json:"duration_seconds"`
+}
+
+type ServiceStatusResDto struct {
+	ID           string             `json:"id"`
+	Title        string             `json:"title"`
+	Status       string             `json:"status"` // up, down, unknown
+	LastChecked  *time.Time         `json:"last_checked"`
+	Uptime       UptimeResDto       `json:"uptime"`
+	ResponseTime ResponseTimeResDto `json:"response_time"`
+	Incidents    []IncidentResDto   `json:"incidents"`
+}
+
+type StatusGroupResDto struct {
+	Group    ServiceGroupResponse  `json:"group"`
+	Services []ServiceStatusResDto `json:"services"`
+}
+
+type StatusPageResponse struct {
+	Status      string              `json:"status"` // operational, degraded, outage
+	GeneratedAt time.Time           `json:"generated_at"`
+	Groups      []StatusGroupResDto `json:"groups"`
+}
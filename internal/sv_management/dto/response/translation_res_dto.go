@@ -0,0 +1,20 @@
+package response
+
+type TranslationResDto struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type MissingTranslationResDto struct {
+	Type   string   `json:"type"` // "service" hoặc "group"
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Locale string   `json:"locale"`
+	Fields []string `json:"fields"`
+}
+
+type MissingTranslationsResponse struct {
+	Locales []string                    `json:"locales"`
+	Items   []*MissingTranslationResDto `json:"items"`
+	Total   int                         `json:"total"`
+}
@@ -0,0 +1,40 @@
+package response
+
+import "time"
+
+type WebhookResDto struct {
+	ID          string    `json:"id"`
+	Url         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Secret chỉ trả về một lần khi hệ thống tự sinh
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookDeliveryResDto struct {
+	ID             string                 `json:"id"`
+	SubscriptionID string                 `json:"subscription_id"`
+	EventID        string                 `json:"event_id"`
+	EventType      string                 `json:"event_type"`
+	Status         string                 `json:"status"`
+	Payload        string                 `json:"payload"`
+	Attempts       []WebhookAttemptResDto `json:"attempts"`
+	NextAttemptAt  *time.Time             `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+type WebhookAttemptResDto struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Items      []*WebhookDeliveryResDto `json:"items"`
+	Pagination PaginationResponse       `json:"pagination"`
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	defaultHistorySize = 1000
+	defaultStreamSize  = 64
+)
+
+// Bus phát event của service layer tới các handler đăng ký (webhook...) và các stream đang mở (SSE).
+// Giữ historySize event gần nhất để client kết nối lại resume từ Last-Event-ID
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Publisher
+	streams  map[*Stream]struct{}
+	history  []Event
+	size     int
+}
+
+func NewBus(historySize int) *Bus {
+	if historySize <= 0 {
+		historySize = defaultHistorySize
+	}
+	return &Bus{
+		streams: map[*Stream]struct{}{},
+		size:    historySize,
+	}
+}
+
+// Handle đăng ký handler nhận mọi event, handler được gọi đồng bộ theo thứ tự đăng ký
+func (b *Bus) Handle(handler Publisher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.Lock()
+	b.history = append(b.history, event)
+	if len(b.history) > b.size {
+		b.history = append(b.history[:0:0], b.history[len(b.history)-b.size:]...)
+	}
+	for s := range b.streams {
+		select {
+		case s.events <- event:
+		default:
+			// stream đọc không kịp thì đóng, client kết nối lại và resume bằng Last-Event-ID
+			b.closeStream(s)
+		}
+	}
+	handlers := b.handlers
+	b.mu.Unlock()
+
+	for _, h := range handlers {
+		h.Publish(ctx, event)
+	}
+}
+
+// Subscribe mở stream nhận event mới. lastEventID khác rỗng thì trả kèm các event sau nó;
+// resumed=false khi không còn event đó trong history, client cần tải lại toàn bộ catalog
+func (b *Bus) Subscribe(lastEventID string) (stream *Stream, missed []Event, resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	resumed = lastEventID == ""
+	if !resumed {
+		for i := len(b.history) - 1; i >= 0; i-- {
+			if b.history[i].ID == lastEventID {
+				missed = append([]Event{}, b.history[i+1:]...)
+				resumed = true
+				break
+			}
+		}
+	}
+
+	stream = &Stream{
+		bus:    b,
+		events: make(chan Event, defaultStreamSize),
+	}
+	b.streams[stream] = struct{}{}
+	return stream, missed, resumed
+}
+
+// Close đóng mọi stream đang mở, dùng khi server tắt để SSE không giữ kết nối
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.streams {
+		b.closeStream(s)
+	}
+}
+
+func (b *Bus) closeStream(s *Stream) {
+	if _, ok := b.streams[s]; ok {
+		delete(b.streams, s)
+		close(s.events)
+	}
+}
+
+// Stream event của một client, channel bị đóng khi client chậm hoặc gọi Close
+type Stream struct {
+	bus    *Bus
+	events chan Event
+}
+
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Stream) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.closeStream(s)
+}
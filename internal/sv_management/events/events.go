@@ -0,0 +1,252 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"services-management/internal/sv_management/model"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Các loại event thay đổi catalog
+const (
+	ServiceCreated = "service.created"
+	ServiceUpdated = "service.updated"
+	ServiceDeleted = "service.deleted"
+	GroupCreated   = "group.created"
+	GroupUpdated   = "group.updated"
+	GroupDeleted   = "group.deleted"
+	GroupReordered = "group.reordered"
+
+	AnnouncementCreated = "announcement.created"
+	AnnouncementUpdated = "announcement.updated"
+	AnnouncementDeleted = "announcement.deleted"
+
+	CollectionCreated = "collection.created"
+	CollectionUpdated = "collection.updated"
+	CollectionDeleted = "collection.deleted"
+)
+
+var Types = []string{
+	ServiceCreated, ServiceUpdated, ServiceDeleted,
+	GroupCreated, GroupUpdated, GroupDeleted, GroupReordered,
+	AnnouncementCreated, AnnouncementUpdated, AnnouncementDeleted,
+	CollectionCreated, CollectionUpdated, CollectionDeleted,
+}
+
+// Event thay đổi của catalog, Data là snapshot sau thay đổi.
+// AggregateID là id của service/group/announcement/collection bị thay đổi, event cùng aggregate giữ đúng thứ tự
+type Event struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	AggregateID string    `json:"aggregate_id"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	Data        any       `json:"data"`
+}
+
+// AggregateType phần trước dấu chấm của Type: service, group, announcement, collection
+func (e Event) AggregateType() string {
+	aggregateType, _, _ := strings.Cut(e.Type, ".")
+	return aggregateType
+}
+
+// Publisher nhận event sau khi thay đổi đã ghi xong, lỗi do publisher tự xử lý
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Recorder chạy write rồi ghi các event nó trả về trong cùng transaction,
+// write hoặc ghi event lỗi thì cả thay đổi bị rollback
+type Recorder interface {
+	Record(ctx context.Context, write func(ctx context.Context) ([]Event, error)) error
+}
+
+type ServiceData struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	GroupID        string    `json:"group_id"`
+	Title          string    `json:"title"`
+	Url            string    `json:"url"`
+	Status         string    `json:"status"`
+	Order          int       `json:"order"`
+	Tags           []string  `json:"tags"`
+	Roles          []string  `json:"roles"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type GroupData struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	ParentID       string    `json:"parent_id"`
+	Path           []string  `json:"path"`
+	Title          string    `json:"title"`
+	Order          int       `json:"order"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ReorderData struct {
+	OrganizationID string   `json:"organization_id"`
+	ParentID       string   `json:"parent_id"`
+	GroupIDs       []string `json:"group_ids"`
+}
+
+type AnnouncementData struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	TargetType     string     `json:"target_type"`
+	TargetID       string     `json:"target_id"`
+	Kind           string     `json:"kind"`
+	Severity       string     `json:"severity"`
+	Message        string     `json:"message"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	DisableLaunch  bool       `json:"disable_launch"`
+}
+
+type CollectionData struct {
+	ID              string    `json:"id"`
+	OrganizationID  string    `json:"organization_id"`
+	Title           string    `json:"title"`
+	Order           int       `json:"order"`
+	Tags            []string  `json:"tags"`
+	MatchAllTags    bool      `json:"match_all_tags"`
+	Roles           []string  `json:"roles"`
+	OrganizationIDs []string  `json:"organization_ids"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func New(eventType, aggregateID string, data any) Event {
+	return Event{
+		ID:          primitive.NewObjectID().Hex(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now(),
+		Data:        data,
+	}
+}
+
+// Decode đọc lại event từ JSON, Data được decode đúng kiểu theo loại event
+func Decode(payload []byte) (Event, error) {
+	var raw struct {
+		Event
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return Event{}, err
+	}
+
+	event := raw.Event
+	var err error
+	event.Data, err = DecodeData(event.Type, raw.Data)
+	return event, err
+}
+
+// DecodeData decode Data của event theo loại event
+func DecodeData(eventType string, raw json.RawMessage) (any, error) {
+	aggregateType, _, _ := strings.Cut(eventType, ".")
+	switch {
+	case eventType == GroupReordered:
+		return decodeData[ReorderData](raw)
+	case aggregateType == "service":
+		return decodeData[ServiceData](raw)
+	case aggregateType == "group":
+		return decodeData[GroupData](raw)
+	case aggregateType == "announcement":
+		return decodeData[AnnouncementData](raw)
+	case aggregateType == "collection":
+		return decodeData[CollectionData](raw)
+	default:
+		return decodeData[any](raw)
+	}
+}
+
+func decodeData[T any](raw json.RawMessage) (T, error) {
+	var data T
+	if len(raw) == 0 || string(raw) == "null" {
+		return data, nil
+	}
+	err := json.Unmarshal(raw, &data)
+	return data, err
+}
+
+func ServiceEvent(eventType string, svc *model.Service) Event {
+	return New(eventType, svc.ID.Hex(), ServiceData{
+		ID:             svc.ID.Hex(),
+		OrganizationID: svc.OrganizationID,
+		GroupID:        svc.GroupID,
+		Title:          svc.Title,
+		Url:            svc.Url,
+		Status:         svc.Status,
+		Order:          svc.Order,
+		Tags:           svc.Tags,
+		Roles:          svc.Roles,
+		UpdatedAt:      svc.UpdatedAt,
+	})
+}
+
+func GroupEvent(eventType string, group *model.ServiceGroup) Event {
+	return New(eventType, group.ID.Hex(), GroupData{
+		ID:             group.ID.Hex(),
+		OrganizationID: group.OrganizationID,
+		ParentID:       group.ParentID,
+		Path:           group.Path,
+		Title:          group.Title,
+		Order:          group.Order,
+		UpdatedAt:      group.UpdatedAt,
+	})
+}
+
+func AnnouncementEvent(eventType string, a *model.Announcement) Event {
+	return New(eventType, a.ID.Hex(), AnnouncementData{
+		ID:             a.ID.Hex(),
+		OrganizationID: a.OrganizationID,
+		TargetType:     a.TargetType,
+		TargetID:       a.TargetID,
+		Kind:           a.Kind,
+		Severity:       a.Severity,
+		Message:        a.Message,
+		StartsAt:       a.StartsAt,
+		EndsAt:         a.EndsAt,
+		DisableLaunch:  a.DisableLaunch,
+	})
+}
+
+func CollectionEvent(eventType string, c *model.SmartCollection) Event {
+	return New(eventType, c.ID.Hex(), CollectionData{
+		ID:              c.ID.Hex(),
+		OrganizationID:  c.OrganizationID,
+		Title:           c.Title,
+		Order:           c.Order,
+		Tags:            c.Query.Tags,
+		MatchAllTags:    c.Query.MatchAllTags,
+		Roles:           c.Query.Roles,
+		OrganizationIDs: c.Query.OrganizationIDs,
+		UpdatedAt:       c.UpdatedAt,
+	})
+}
+
+// Match filter rỗng nhận mọi event, hỗ trợ "*" và wildcard theo nhóm như "service.*"
+func Match(filters []string, eventType string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if f == "*" || f == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(f, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidFilter filter phải khớp ít nhất một loại event
+func ValidFilter(filter string) bool {
+	return slices.ContainsFunc(Types, func(t string) bool {
+		return Match([]string{filter}, t)
+	})
+}
@@ -0,0 +1,81 @@
+package eventstore
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+)
+
+// CatalogProjection tên projection dựng read model catalog
+const CatalogProjection = "catalog"
+
+// CatalogProjector dựng read model catalog (service, group) từ event,
+// event mang snapshot sau thay đổi nên áp dụng lại vẫn cho cùng kết quả
+type CatalogProjector struct {
+	views repository.CatalogViewRepository
+}
+
+func NewCatalogProjector(views repository.CatalogViewRepository) *CatalogProjector {
+	return &CatalogProjector{
+		views: views,
+	}
+}
+
+func (p *CatalogProjector) Apply(ctx context.Context, record Record) error {
+	event := record.Event
+	switch data := event.Data.(type) {
+	case events.ServiceData:
+		id := StreamName("service", event.AggregateID)
+		if event.Type == events.ServiceDeleted {
+			return p.views.Delete(ctx, id)
+		}
+		return p.views.Upsert(ctx, &model.CatalogView{
+			ID:             id,
+			AggregateType:  "service",
+			AggregateID:    event.AggregateID,
+			OrganizationID: data.OrganizationID,
+			ParentID:       data.GroupID,
+			Title:          data.Title,
+			Url:            data.Url,
+			Status:         data.Status,
+			Order:          data.Order,
+			Tags:           data.Tags,
+			Roles:          data.Roles,
+			Version:        record.Version,
+			LastEventID:    event.ID,
+			UpdatedAt:      data.UpdatedAt,
+		})
+	case events.GroupData:
+		id := StreamName("group", event.AggregateID)
+		if event.Type == events.GroupDeleted {
+			return p.views.Delete(ctx, id)
+		}
+		return p.views.Upsert(ctx, &model.CatalogView{
+			ID:             id,
+			AggregateType:  "group",
+			AggregateID:    event.AggregateID,
+			OrganizationID: data.OrganizationID,
+			ParentID:       data.ParentID,
+			Path:           data.Path,
+			Title:          data.Title,
+			Order:          data.Order,
+			Version:        record.Version,
+			LastEventID:    event.ID,
+			UpdatedAt:      data.UpdatedAt,
+		})
+	case events.ReorderData:
+		ids := make([]string, len(data.GroupIDs))
+		for i, groupID := range data.GroupIDs {
+			ids[i] = StreamName("group", groupID)
+		}
+		return p.views.UpdateOrders(ctx, ids, event.ID)
+	default:
+		// announcement, collection và event hệ thống không thuộc read model này
+		return nil
+	}
+}
+
+func (p *CatalogProjector) Reset(ctx context.Context) error {
+	return p.views.Clear(ctx)
+}
@@ -0,0 +1,163 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"services-management/internal/sv_management/events"
+	"slices"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/gofrs/uuid"
+)
+
+// eventMetadata metadata ghi kèm mỗi event, Data của EventStoreDB chỉ chứa Data của event
+type eventMetadata struct {
+	EventID     string    `json:"event_id"`
+	AggregateID string    `json:"aggregate_id"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+type esdbStore struct {
+	client *esdb.Client
+}
+
+// NewESDBStore kết nối EventStoreDB, connectionString dạng esdb://host:2113?tls=false
+func NewESDBStore(connectionString string) (Store, error) {
+	cfg, err := esdb.ParseConnectionString(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	client, err := esdb.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &esdbStore{
+		client: client,
+	}, nil
+}
+
+func (s *esdbStore) Append(ctx context.Context, stream string, recorded []events.Event) error {
+	proposed := make([]esdb.EventData, 0, len(recorded))
+	for _, event := range recorded {
+		data, err := json.Marshal(event.Data)
+		if err != nil {
+			return err
+		}
+		metadata, err := json.Marshal(eventMetadata{
+			EventID:     event.ID,
+			AggregateID: event.AggregateID,
+			OccurredAt:  event.OccurredAt,
+		})
+		if err != nil {
+			return err
+		}
+		proposed = append(proposed, esdb.EventData{
+			// id cố định theo event để EventStoreDB bỏ qua lần ghi lại khi relay retry
+			EventID:     uuid.NewV5(uuid.NamespaceURL, "services-management/events/"+event.ID),
+			EventType:   event.Type,
+			ContentType: esdb.JsonContentType,
+			Data:        data,
+			Metadata:    metadata,
+		})
+	}
+
+	_, err := s.client.AppendToStream(ctx, stream, esdb.AppendToStreamOptions{ExpectedRevision: esdb.Any{}}, proposed...)
+	return err
+}
+
+func (s *esdbStore) ReadStream(ctx context.Context, stream string, from uint64, limit int) ([]Record, error) {
+	read, err := s.client.ReadStream(ctx, stream, esdb.ReadStreamOptions{
+		Direction: esdb.Forwards,
+		From:      esdb.Revision(from),
+	}, uint64(limit))
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return []Record{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer read.Close()
+
+	records, err := collect(read, nil)
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return []Record{}, nil
+	}
+	return records, err
+}
+
+func (s *esdbStore) ReadAll(ctx context.Context, after *Position, limit int) ([]Record, error) {
+	opts := esdb.ReadAllOptions{Direction: esdb.Forwards, From: esdb.Start{}}
+	count := uint64(limit)
+	if after != nil {
+		// đọc từ một vị trí trả về cả event tại vị trí đó nên đọc dư một event
+		opts.From = esdb.Position{Commit: after.Commit, Prepare: after.Prepare}
+		count++
+	}
+
+	read, err := s.client.ReadAll(ctx, opts, count)
+	if err != nil {
+		return nil, err
+	}
+	defer read.Close()
+
+	return collect(read, after)
+}
+
+func (s *esdbStore) Close() error {
+	return s.client.Close()
+}
+
+func collect(read *esdb.ReadStream, after *Position) ([]Record, error) {
+	records := make([]Record, 0)
+	for {
+		resolved, err := read.Recv()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := toRecord(resolved.OriginalEvent())
+		if err != nil {
+			return nil, err
+		}
+		if after != nil && record.Position == *after {
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+func toRecord(recorded *esdb.RecordedEvent) (Record, error) {
+	record := Record{
+		Stream:   recorded.StreamID,
+		Version:  recorded.EventNumber,
+		Position: Position{Commit: recorded.Position.Commit, Prepare: recorded.Position.Prepare},
+		Event: events.Event{
+			ID:         recorded.EventID.String(),
+			Type:       recorded.EventType,
+			OccurredAt: recorded.CreatedDate,
+		},
+	}
+	if !slices.Contains(events.Types, recorded.EventType) {
+		return record, nil
+	}
+
+	var metadata eventMetadata
+	if err := json.Unmarshal(recorded.UserMetadata, &metadata); err != nil {
+		return Record{}, err
+	}
+	data, err := events.DecodeData(recorded.EventType, recorded.Data)
+	if err != nil {
+		return Record{}, err
+	}
+	record.Event.ID = metadata.EventID
+	record.Event.AggregateID = metadata.AggregateID
+	record.Event.OccurredAt = metadata.OccurredAt
+	record.Event.Data = data
+	return record, nil
+}
@@ -0,0 +1,80 @@
+package eventstore
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+	"sync"
+)
+
+// MemoryStore giữ event trong bộ nhớ, dùng trong test
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records []Record
+	ids     map[string]bool
+	streams map[string]uint64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		ids:     make(map[string]bool),
+		streams: make(map[string]uint64),
+	}
+}
+
+func (s *MemoryStore) Append(_ context.Context, stream string, recorded []events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range recorded {
+		if s.ids[event.ID] {
+			continue
+		}
+		s.ids[event.ID] = true
+
+		// vị trí bắt đầu từ 1 để phân biệt với Position rỗng
+		position := uint64(len(s.records) + 1)
+		s.records = append(s.records, Record{
+			Stream:   stream,
+			Version:  s.streams[stream],
+			Position: Position{Commit: position, Prepare: position},
+			Event:    event,
+		})
+		s.streams[stream]++
+	}
+	return nil
+}
+
+func (s *MemoryStore) ReadStream(_ context.Context, stream string, from uint64, limit int) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Record, 0)
+	for _, record := range s.records {
+		if len(result) == limit {
+			break
+		}
+		if record.Stream == stream && record.Version >= from {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+func (s *MemoryStore) ReadAll(_ context.Context, after *Position, limit int) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := 0
+	if after != nil {
+		start = int(after.Commit)
+	}
+	if start >= len(s.records) {
+		return []Record{}, nil
+	}
+	end := min(start+limit, len(s.records))
+	return append([]Record{}, s.records[start:end]...), nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
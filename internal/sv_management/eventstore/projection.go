@@ -0,0 +1,189 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"services-management/pkg/constants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultBatchSize    = 200
+	writeTimeout        = 10 * time.Second
+)
+
+// Projector áp dụng event vào read model.
+// Apply phải idempotent vì batch lỗi giữa chừng sẽ được áp dụng lại từ checkpoint trước
+type Projector interface {
+	Apply(ctx context.Context, record Record) error
+	// Reset xoá read model trước khi replay
+	Reset(ctx context.Context) error
+}
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// Projection đọc $all từ checkpoint và áp dụng cho projector, lưu checkpoint sau mỗi batch.
+// Chỉ instance giữ lease mới chạy, replay được ghi thành yêu cầu để instance đó thực hiện
+type Projection struct {
+	name        string
+	store       Store
+	projector   Projector
+	checkpoints repository.ProjectionCheckpointRepository
+	leaseRepo   repository.LeaseRepository
+	owner       string
+	opts        Options
+	wake        chan struct{}
+}
+
+func NewProjection(name string, store Store, projector Projector, checkpoints repository.ProjectionCheckpointRepository, leaseRepo repository.LeaseRepository, opts Options) *Projection {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	return &Projection{
+		name:        name,
+		store:       store,
+		projector:   projector,
+		checkpoints: checkpoints,
+		leaseRepo:   leaseRepo,
+		owner:       primitive.NewObjectID().Hex(),
+		opts:        opts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+func (p *Projection) Name() string {
+	return p.name
+}
+
+// Notify đánh thức projection thay vì đợi tới chu kỳ poll
+func (p *Projection) Notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start chạy projection tới khi ctx bị huỷ
+func (p *Projection) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.opts.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := p.Run(ctx); err != nil {
+				logger.WriteLogEx("error", constants.MongoProjection+" projection failed", map[string]any{
+					"projection": p.name,
+					"error":      err.Error(),
+				})
+			}
+			select {
+			case <-ctx.Done():
+				releaseCtx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+				_ = p.leaseRepo.Release(releaseCtx, p.leaseName(), p.owner)
+				cancel()
+				return
+			case <-ticker.C:
+			case <-p.wake:
+			}
+		}
+	}()
+}
+
+// Run áp dụng mọi event mới nếu instance giữ được lease, có yêu cầu replay thì dựng lại từ đầu
+func (p *Projection) Run(ctx context.Context) error {
+	leader, err := p.leaseRepo.Acquire(ctx, p.leaseName(), p.owner, 5*p.opts.PollInterval+writeTimeout)
+	if err != nil || !leader {
+		return err
+	}
+
+	checkpoint, err := p.checkpoints.Get(ctx, p.name)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		checkpoint = &model.ProjectionCheckpoint{Name: p.name}
+	} else if err != nil {
+		return err
+	}
+
+	if checkpoint.ReplayRequested {
+		logger.WriteLogEx("info", constants.MongoProjection+" replay started", map[string]any{
+			"projection": p.name,
+		})
+		if err := p.projector.Reset(ctx); err != nil {
+			return err
+		}
+		if err := p.checkpoints.ResetForReplay(ctx, p.name); err != nil {
+			return err
+		}
+		checkpoint = &model.ProjectionCheckpoint{Name: p.name}
+	}
+
+	for ctx.Err() == nil {
+		var after *Position
+		if checkpoint.Started {
+			after = &Position{Commit: checkpoint.Commit, Prepare: checkpoint.Prepare}
+		}
+
+		records, err := p.store.ReadAll(ctx, after, p.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		for _, record := range records {
+			if err := p.projector.Apply(ctx, record); err != nil {
+				return fmt.Errorf("apply %s@%d: %w", record.Stream, record.Version, err)
+			}
+		}
+
+		last := records[len(records)-1].Position
+		checkpoint.Started = true
+		checkpoint.Commit = last.Commit
+		checkpoint.Prepare = last.Prepare
+		checkpoint.Processed += int64(len(records))
+
+		writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+		err = p.checkpoints.Save(writeCtx, checkpoint)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Replay yêu cầu dựng lại read model từ đầu stream, instance giữ lease thực hiện ở lượt chạy sau
+func (p *Projection) Replay(ctx context.Context) error {
+	if err := p.checkpoints.RequestReplay(ctx, p.name); err != nil {
+		return err
+	}
+	p.Notify()
+	return nil
+}
+
+// Checkpoint vị trí hiện tại của projection, chưa chạy lần nào thì trả về checkpoint rỗng
+func (p *Projection) Checkpoint(ctx context.Context) (*model.ProjectionCheckpoint, error) {
+	checkpoint, err := p.checkpoints.Get(ctx, p.name)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &model.ProjectionCheckpoint{Name: p.name}, nil
+	}
+	return checkpoint, err
+}
+
+func (p *Projection) leaseName() string {
+	return "projection:" + p.name
+}
@@ -0,0 +1,263 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryCheckpoints giữ checkpoint trong bộ nhớ, Save giữ nguyên yêu cầu replay như bản mongo
+type memoryCheckpoints struct {
+	mu          sync.Mutex
+	checkpoints map[string]model.ProjectionCheckpoint
+}
+
+func newMemoryCheckpoints() *memoryCheckpoints {
+	return &memoryCheckpoints{checkpoints: make(map[string]model.ProjectionCheckpoint)}
+}
+
+func (c *memoryCheckpoints) Get(_ context.Context, name string) (*model.ProjectionCheckpoint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checkpoint, ok := c.checkpoints[name]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &checkpoint, nil
+}
+
+func (c *memoryCheckpoints) Save(_ context.Context, checkpoint *model.ProjectionCheckpoint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	saved := *checkpoint
+	saved.ReplayRequested = c.checkpoints[checkpoint.Name].ReplayRequested
+	saved.UpdatedAt = time.Now()
+	c.checkpoints[checkpoint.Name] = saved
+	return nil
+}
+
+func (c *memoryCheckpoints) RequestReplay(_ context.Context, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checkpoint := c.checkpoints[name]
+	checkpoint.Name = name
+	checkpoint.ReplayRequested = true
+	c.checkpoints[name] = checkpoint
+	return nil
+}
+
+func (c *memoryCheckpoints) ResetForReplay(_ context.Context, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.checkpoints[name].ReplayRequested {
+		c.checkpoints[name] = model.ProjectionCheckpoint{Name: name, UpdatedAt: time.Now()}
+	}
+	return nil
+}
+
+// singleLease lease luôn thuộc về người gọi
+type singleLease struct{}
+
+func (singleLease) Acquire(context.Context, string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (singleLease) Release(context.Context, string, string) error {
+	return nil
+}
+
+// memoryViews read model catalog trong bộ nhớ, đếm số event đã áp dụng
+type memoryViews struct {
+	mu      sync.Mutex
+	views   map[string]*model.CatalogView
+	applied int
+	resets  int
+}
+
+func newMemoryViews() *memoryViews {
+	return &memoryViews{views: make(map[string]*model.CatalogView)}
+}
+
+func (v *memoryViews) Upsert(_ context.Context, view *model.CatalogView) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.applied++
+	v.views[view.ID] = view
+	return nil
+}
+
+func (v *memoryViews) Delete(_ context.Context, id string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.applied++
+	delete(v.views, id)
+	return nil
+}
+
+func (v *memoryViews) UpdateOrders(_ context.Context, ids []string, eventID string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.applied++
+	for i, id := range ids {
+		if view, ok := v.views[id]; ok {
+			view.Order = i + 1
+			view.LastEventID = eventID
+		}
+	}
+	return nil
+}
+
+func (v *memoryViews) Clear(context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.resets++
+	v.views = make(map[string]*model.CatalogView)
+	return nil
+}
+
+func (v *memoryViews) FindByOrganization(_ context.Context, organizationID, aggregateType string) ([]*model.CatalogView, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var result []*model.CatalogView
+	for _, view := range v.views {
+		if view.OrganizationID == organizationID && view.AggregateType == aggregateType {
+			result = append(result, view)
+		}
+	}
+	return result, nil
+}
+
+func (v *memoryViews) EnsureIndexes(context.Context) error {
+	return nil
+}
+
+var eventSeq int
+
+func appendServiceEvent(t *testing.T, store Store, eventType, serviceID, title string) {
+	t.Helper()
+	eventSeq++
+	event := events.Event{
+		ID:          fmt.Sprintf("evt-%d", eventSeq),
+		Type:        eventType,
+		AggregateID: serviceID,
+		OccurredAt:  time.Now(),
+		Data:        events.ServiceData{ID: serviceID, OrganizationID: "org-1", Title: title},
+	}
+	if err := store.Append(context.Background(), StreamName("service", serviceID), []events.Event{event}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestProjection(store Store, views *memoryViews, checkpoints *memoryCheckpoints) *Projection {
+	// batch nhỏ để projection phải lưu checkpoint nhiều lần
+	return NewProjection(CatalogProjection, store, NewCatalogProjector(views), checkpoints, singleLease{}, Options{BatchSize: 2})
+}
+
+func TestProjectionAppliesEvents(t *testing.T) {
+	store := NewMemoryStore()
+	appendServiceEvent(t, store, events.ServiceCreated, "s1", "Mail")
+	appendServiceEvent(t, store, events.ServiceCreated, "s2", "Drive")
+	appendServiceEvent(t, store, events.ServiceUpdated, "s1", "Mail v2")
+	appendServiceEvent(t, store, events.ServiceDeleted, "s2", "Drive")
+
+	views := newMemoryViews()
+	checkpoints := newMemoryCheckpoints()
+	if err := newTestProjection(store, views, checkpoints).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(views.views) != 1 {
+		t.Fatalf("%d views, want 1", len(views.views))
+	}
+	view := views.views[StreamName("service", "s1")]
+	if view == nil || view.Title != "Mail v2" || view.Version != 1 || view.OrganizationID != "org-1" {
+		t.Fatalf("view s1 = %+v, want title Mail v2 at version 1", view)
+	}
+
+	checkpoint, _ := checkpoints.Get(context.Background(), CatalogProjection)
+	if !checkpoint.Started || checkpoint.Commit != 4 || checkpoint.Processed != 4 {
+		t.Fatalf("checkpoint = %+v, want started at commit 4 with 4 processed", checkpoint)
+	}
+}
+
+func TestProjectionResumesFromCheckpoint(t *testing.T) {
+	store := NewMemoryStore()
+	appendServiceEvent(t, store, events.ServiceCreated, "s1", "Mail")
+	appendServiceEvent(t, store, events.ServiceCreated, "s2", "Drive")
+	appendServiceEvent(t, store, events.ServiceCreated, "s3", "Chat")
+
+	views := newMemoryViews()
+	checkpoints := newMemoryCheckpoints()
+	if err := newTestProjection(store, views, checkpoints).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if views.applied != 3 {
+		t.Fatalf("applied %d events, want 3", views.applied)
+	}
+
+	// instance khác đọc cùng checkpoint chỉ áp dụng event mới
+	appendServiceEvent(t, store, events.ServiceUpdated, "s3", "Chat v2")
+	if err := newTestProjection(store, views, checkpoints).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if views.applied != 4 {
+		t.Fatalf("applied %d events after resume, want 4", views.applied)
+	}
+	if title := views.views[StreamName("service", "s3")].Title; title != "Chat v2" {
+		t.Fatalf("view s3 title = %q, want Chat v2", title)
+	}
+
+	checkpoint, _ := checkpoints.Get(context.Background(), CatalogProjection)
+	if checkpoint.Commit != 4 || checkpoint.Processed != 4 {
+		t.Fatalf("checkpoint = %+v, want commit 4 with 4 processed", checkpoint)
+	}
+}
+
+func TestProjectionReplay(t *testing.T) {
+	store := NewMemoryStore()
+	appendServiceEvent(t, store, events.ServiceCreated, "s1", "Mail")
+	appendServiceEvent(t, store, events.ServiceCreated, "s2", "Drive")
+	appendServiceEvent(t, store, events.ServiceUpdated, "s1", "Mail v2")
+
+	views := newMemoryViews()
+	checkpoints := newMemoryCheckpoints()
+	projection := newTestProjection(store, views, checkpoints)
+	if err := projection.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// read model bị sửa ngoài luồng, replay phải dựng lại đúng từ event
+	views.views[StreamName("service", "s1")].Title = "broken"
+	views.views["service-stale"] = &model.CatalogView{ID: "service-stale"}
+
+	if err := projection.Replay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint, _ := projection.Checkpoint(context.Background()); !checkpoint.ReplayRequested {
+		t.Fatalf("checkpoint = %+v, want replay requested", checkpoint)
+	}
+	if err := projection.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if views.resets != 1 {
+		t.Fatalf("read model reset %d times, want 1", views.resets)
+	}
+	if len(views.views) != 2 {
+		t.Fatalf("%d views after replay, want 2", len(views.views))
+	}
+	if title := views.views[StreamName("service", "s1")].Title; title != "Mail v2" {
+		t.Fatalf("view s1 title = %q, want Mail v2", title)
+	}
+
+	checkpoint, _ := projection.Checkpoint(context.Background())
+	if checkpoint.ReplayRequested || checkpoint.Commit != 3 || checkpoint.Processed != 3 {
+		t.Fatalf("checkpoint = %+v, want replay done at commit 3 with 3 processed", checkpoint)
+	}
+}
@@ -0,0 +1,43 @@
+package eventstore
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+	"strings"
+)
+
+// Position vị trí của event trong toàn bộ store ($all), tăng dần theo thứ tự ghi
+type Position struct {
+	Commit  uint64 `json:"commit"`
+	Prepare uint64 `json:"prepare"`
+}
+
+// Record event đã ghi vào store.
+// Event không phải của catalog (event hệ thống của EventStoreDB...) chỉ có Type, Data nil
+type Record struct {
+	Stream   string       `json:"stream"`
+	Version  uint64       `json:"version"` // số thứ tự trong stream, bắt đầu từ 0
+	Position Position     `json:"position"`
+	Event    events.Event `json:"event"`
+}
+
+// Store lưu event catalog theo stream của từng aggregate
+type Store interface {
+	// Append ghi event vào cuối stream, ghi lại event đã có (cùng id) không tạo bản trùng
+	Append(ctx context.Context, stream string, recorded []events.Event) error
+	// ReadStream đọc event của một stream từ version from, stream chưa có thì trả về rỗng
+	ReadStream(ctx context.Context, stream string, from uint64, limit int) ([]Record, error)
+	// ReadAll đọc event của mọi stream theo thứ tự ghi, sau vị trí after (nil là từ đầu)
+	ReadAll(ctx context.Context, after *Position, limit int) ([]Record, error)
+	Close() error
+}
+
+// StreamName stream của một aggregate: "service-<id>", "group-<id>"...
+func StreamName(aggregateType, aggregateID string) string {
+	return aggregateType + "-" + aggregateID
+}
+
+// ParseStreamName tách stream thành loại và id của aggregate
+func ParseStreamName(stream string) (aggregateType, aggregateID string, ok bool) {
+	return strings.Cut(stream, "-")
+}
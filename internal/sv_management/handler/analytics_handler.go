@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	service service.AnalyticsService
+}
+
+func NewAnalyticsHandler(service service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		service: service,
+	}
+}
+
+func (s *AnalyticsHandler) TopServices(c *gin.Context) {
+	var req request.UsageReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	report, err := s.service.TopServices(c.Request.Context(), req)
+	if err != nil {
+		sendAnalyticsError(c, err)
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(report.Items))
+		for _, item := range report.Items {
+			rows = append(rows, []string{item.ServiceID, item.Title, itoa(item.Clicks), itoa(item.UniqueUsers)})
+		}
+		helper.SendCSV(c, fmt.Sprintf("top-services_%s_%s.csv", report.From, report.To),
+			[]string{"service_id", "title", "clicks", "unique_users"}, rows)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get top services successfully", report)
+}
+
+func (s *AnalyticsHandler) DailyActiveUsers(c *gin.Context) {
+	var req request.DailyActiveUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	report, err := s.service.DailyActiveUsers(c.Request.Context(), req)
+	if err != nil {
+		sendAnalyticsError(c, err)
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(report.Items))
+		for _, item := range report.Items {
+			rows = append(rows, []string{item.Day, itoa(item.Clicks), itoa(item.ActiveUsers)})
+		}
+		helper.SendCSV(c, fmt.Sprintf("daily-active-users_%s_%s_%s.csv", report.ServiceID, report.From, report.To),
+			[]string{"day", "clicks", "active_users"}, rows)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get daily active users successfully", report)
+}
+
+func (s *AnalyticsHandler) NeverUsed(c *gin.Context) {
+	var req request.UsageReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	services, err := s.service.NeverUsedServices(c.Request.Context(), req)
+	if err != nil {
+		sendAnalyticsError(c, err)
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(services))
+		for _, svc := range services {
+			rows = append(rows, []string{svc.ID, svc.Title, svc.Url, svc.OrganizationID})
+		}
+		helper.SendCSV(c, "never-used-services.csv",
+			[]string{"service_id", "title", "url", "organization_id"}, rows)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get never used services successfully", services)
+}
+
+func (s *AnalyticsHandler) Trends(c *gin.Context) {
+	var req request.UsageReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	report, err := s.service.Trends(c.Request.Context(), req)
+	if err != nil {
+		sendAnalyticsError(c, err)
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(report.Items))
+		for _, item := range report.Items {
+			changePercent := ""
+			if item.ChangePercent != nil {
+				changePercent = strconv.FormatFloat(*item.ChangePercent, 'f', 2, 64)
+			}
+			rows = append(rows, []string{
+				item.ServiceID, item.Title, itoa(item.Clicks), itoa(item.PreviousClicks), itoa(item.Change), changePercent,
+			})
+		}
+		helper.SendCSV(c, fmt.Sprintf("trends_%s_%s.csv", report.From, report.To),
+			[]string{"service_id", "title", "clicks", "previous_clicks", "change", "change_percent"}, rows)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get trends successfully", report)
+}
+
+func (s *AnalyticsHandler) RunRollup(c *gin.Context) {
+	if err := s.service.RunRollup(c.Request.Context()); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Run usage rollup successfully", nil)
+}
+
+func sendAnalyticsError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidDateRange) {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnnouncementHandler struct {
+	service service.AnnouncementService
+}
+
+func NewAnnouncementHandler(service service.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		service: service,
+	}
+}
+
+func (s *AnnouncementHandler) Create(c *gin.Context) {
+	var req request.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	announcement, err := s.service.Create(c.Request.Context(), req)
+	if err != nil {
+		sendAnnouncementError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Create announcement successfully", announcement)
+}
+
+func (s *AnnouncementHandler) Update(c *gin.Context) {
+	var req request.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	announcement, err := s.service.Update(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendAnnouncementError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Update announcement successfully", announcement)
+}
+
+func (s *AnnouncementHandler) Delete(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		sendAnnouncementError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete announcement successfully", nil)
+}
+
+func (s *AnnouncementHandler) List(c *gin.Context) {
+	var req request.ListAnnouncementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	announcements, err := s.service.List(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get announcements successfully", announcements)
+}
+
+func sendAnnouncementError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAnnouncementNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrServiceNotFound), errors.Is(err, service.ErrServiceGroupNotFound),
+		errors.Is(err, service.ErrInvalidAnnouncementWindow), errors.Is(err, service.ErrMaintenanceEndRequired):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	service "services-management/internal/sv_management/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetHandler struct {
+	service service.AssetService
+}
+
+func NewAssetHandler(service service.AssetService) *AssetHandler {
+	return &AssetHandler{
+		service: service,
+	}
+}
+
+func (s *AssetHandler) Upload(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	asset, err := s.service.Upload(c.Request.Context(), file, c.PostForm("organization_id"))
+	switch {
+	case errors.Is(err, service.ErrAssetTooLarge), errors.Is(err, service.ErrAssetTooLargeDimensions):
+		helper.SendError(c, http.StatusRequestEntityTooLarge, err, helper.ErrInvalidRequest)
+		return
+	case errors.Is(err, service.ErrInvalidAsset):
+		helper.SendError(c, http.StatusUnsupportedMediaType, err, helper.ErrInvalidRequest)
+		return
+	case err != nil:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Upload asset successfully", asset)
+}
+
+// Serve trả file asset, nội dung theo id không đổi nên cache lâu dài
+func (s *AssetHandler) Serve(c *gin.Context) {
+	size, _ := strconv.Atoi(c.Query("size"))
+
+	content, err := s.service.Open(c.Request.Context(), c.Param("id"), size)
+	if errors.Is(err, service.ErrAssetNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	defer content.Reader.Close()
+
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", content.ETag)
+	c.Header("X-Content-Type-Options", "nosniff")
+	if content.ContentType == "image/svg+xml" {
+		// SVG có thể chứa script
+		c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	}
+
+	if c.GetHeader("If-None-Match") == content.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.DataFromReader(http.StatusOK, content.Size, content.ContentType, content.Reader, nil)
+}
+
+func (s *AssetHandler) Delete(c *gin.Context) {
+	err := s.service.Delete(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, service.ErrAssetNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	if errors.Is(err, service.ErrAssetInUse) {
+		helper.SendError(c, http.StatusConflict, err, helper.ErrInvalidOperation)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete asset successfully", nil)
+}
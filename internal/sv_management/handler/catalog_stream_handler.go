@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/events"
+	service "services-management/internal/sv_management/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventReset báo client tải lại toàn bộ catalog vì không resume được từ Last-Event-ID
+const eventReset = "reset"
+
+type CatalogStreamHandler struct {
+	service   service.CatalogStreamService
+	heartbeat time.Duration
+	retry     time.Duration
+}
+
+func NewCatalogStreamHandler(service service.CatalogStreamService, heartbeat, retry time.Duration) *CatalogStreamHandler {
+	return &CatalogStreamHandler{
+		service:   service,
+		heartbeat: heartbeat,
+		retry:     retry,
+	}
+}
+
+// Stream Server-Sent Events: mỗi event gồm id, event (loại) và data JSON của event catalog,
+// comment heartbeat định kỳ để proxy không cắt kết nối
+func (s *CatalogStreamHandler) Stream(c *gin.Context) {
+	var req request.CatalogStreamRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = req.LastEventID
+	}
+
+	ctx := c.Request.Context()
+	sub, err := s.service.Subscribe(ctx, lastEventID)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", s.retry.Milliseconds())
+	if !sub.Resumed {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", eventReset)
+	}
+	for _, event := range sub.Missed {
+		writeSSE(w, event)
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			writeSSE(w, event)
+		}
+		w.Flush()
+	}
+}
+
+func writeSSE(w io.Writer, event events.Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
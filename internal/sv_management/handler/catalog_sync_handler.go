@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogSyncHandler struct {
+	service service.CatalogSyncService
+}
+
+func NewCatalogSyncHandler(service service.CatalogSyncService) *CatalogSyncHandler {
+	return &CatalogSyncHandler{
+		service: service,
+	}
+}
+
+func (s *CatalogSyncHandler) Changes(c *gin.Context) {
+	var req request.CatalogChangesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	changes, err := s.service.Changes(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get catalog changes successfully", changes)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DependencyHandler struct {
+	service service.DependencyService
+}
+
+func NewDependencyHandler(service service.DependencyService) *DependencyHandler {
+	return &DependencyHandler{
+		service: service,
+	}
+}
+
+func (s *DependencyHandler) SetDependencies(c *gin.Context) {
+	var req request.SetDependenciesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	dependencies, err := s.service.SetDependencies(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendDependencyError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Update service dependencies successfully", dependencies)
+}
+
+func (s *DependencyHandler) Graph(c *gin.Context) {
+	var req request.DependencyGraphRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	if req.Format == "dot" {
+		dot, err := s.service.GraphDOT(c.Request.Context(), req)
+		if err != nil {
+			helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+			return
+		}
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(dot))
+		return
+	}
+
+	graph, err := s.service.Graph(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get dependency graph successfully", graph)
+}
+
+func (s *DependencyHandler) Impact(c *gin.Context) {
+	impact, err := s.service.Impact(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		sendDependencyError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get service impact successfully", impact)
+}
+
+func sendDependencyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrServiceNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrDependencyNotFound):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	case errors.Is(err, service.ErrDependencyCycle):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidOperation)
+	case errors.Is(err, service.ErrDependencyLocked):
+		helper.SendError(c, http.StatusConflict, err, helper.ErrInvalidOperation)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	service service.HealthService
+}
+
+func NewHealthHandler(service service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		service: service,
+	}
+}
+
+func (s *HealthHandler) Failing(c *gin.Context) {
+	var req request.FailingServicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	failing, err := s.service.FailingServices(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(failing))
+		for _, f := range failing {
+			impacted := make([]string, 0, len(f.ImpactedServices))
+			for _, i := range f.ImpactedServices {
+				impacted = append(impacted, i.Title)
+			}
+			rows = append(rows, []string{
+				f.ServiceID, f.Title, f.Url, f.OrganizationID, strconv.Itoa(f.StatusCode), f.Error,
+				f.LastChecked.Format(time.RFC3339), f.FailingSince.Format(time.RFC3339), strings.Join(impacted, "; "),
+			})
+		}
+		helper.SendCSV(c, "failing-services.csv", []string{
+			"service_id", "title", "url", "organization_id", "status_code", "error", "last_checked", "failing_since", "impacted_services",
+		}, rows)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get failing services successfully", failing)
+}
+
+func (s *HealthHandler) History(c *gin.Context) {
+	var req request.HealthHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	history, err := s.service.History(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendHealthError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get health history successfully", history)
+}
+
+func (s *HealthHandler) Configure(c *gin.Context) {
+	var req request.HealthCheckConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	cfg, err := s.service.ConfigureCheck(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendHealthError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Configure health check successfully", cfg)
+}
+
+func (s *HealthHandler) CheckNow(c *gin.Context) {
+	check, err := s.service.CheckNow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		sendHealthError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Check service health successfully", check)
+}
+
+func (s *HealthHandler) MarkStatus(c *gin.Context) {
+	var req request.MarkHealthStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	impact, err := s.service.MarkStatus(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendHealthError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Mark service health successfully", impact)
+}
+
+func (s *HealthHandler) RunAll(c *gin.Context) {
+	if err := s.service.RunAll(c.Request.Context()); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Run health probe successfully", nil)
+}
+
+func sendHealthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrServiceNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrServiceNotProbeable):
+		helper.SendError(c, http.StatusUnprocessableEntity, err, helper.ErrInvalidOperation)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	service "services-management/internal/sv_management/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LaunchHandler struct {
+	service service.LaunchService
+}
+
+func NewLaunchHandler(service service.LaunchService) *LaunchHandler {
+	return &LaunchHandler{
+		service: service,
+	}
+}
+
+func (s *LaunchHandler) Launch(c *gin.Context) {
+	url, err := s.service.Launch(c.Request.Context(), c.Param("serviceId"), c.Request.UserAgent())
+	if errors.Is(err, service.ErrServiceNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	var maintenance *service.MaintenanceError
+	if errors.As(err, &maintenance) {
+		if maintenance.Until != nil {
+			retryAfter := int(time.Until(*maintenance.Until).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+		}
+		helper.SendError(c, http.StatusServiceUnavailable, err, helper.ErrInvalidOperation)
+		return
+	}
+	if errors.Is(err, service.ErrUrlValueMissing) {
+		helper.SendError(c, http.StatusUnprocessableEntity, err, helper.ErrInvalidOperation)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+
+	// redirect phụ thuộc quyền của user nên không cho cache
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, url)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OwnershipHandler struct {
+	service service.OwnershipService
+}
+
+func NewOwnershipHandler(service service.OwnershipService) *OwnershipHandler {
+	return &OwnershipHandler{
+		service: service,
+	}
+}
+
+func (s *OwnershipHandler) SetOwnership(c *gin.Context) {
+	var req request.OwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	ownership, err := s.service.SetOwnership(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendOwnershipError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Update service ownership successfully", ownership)
+}
+
+func (s *OwnershipHandler) ClearOwnership(c *gin.Context) {
+	if err := s.service.ClearOwnership(c.Request.Context(), c.Param("id")); err != nil {
+		sendOwnershipError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Clear service ownership successfully", nil)
+}
+
+func (s *OwnershipHandler) ServicesByOwner(c *gin.Context) {
+	var req request.OwnerServicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	owners, err := s.service.ServicesByOwner(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get services by owner successfully", owners)
+}
+
+func (s *OwnershipHandler) Support(c *gin.Context) {
+	support, err := s.service.Support(c.Request.Context(), c.Param("serviceId"))
+	if err != nil {
+		sendOwnershipError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get service support successfully", support)
+}
+
+func sendOwnershipError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrServiceNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectionHandler struct {
+	service service.ProjectionService
+}
+
+func NewProjectionHandler(service service.ProjectionService) *ProjectionHandler {
+	return &ProjectionHandler{
+		service: service,
+	}
+}
+
+func (s *ProjectionHandler) Status(c *gin.Context) {
+	status, err := s.service.Status(c.Request.Context())
+	if err != nil {
+		sendProjectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get projection status successfully", status)
+}
+
+func (s *ProjectionHandler) Replay(c *gin.Context) {
+	if err := s.service.Replay(c.Request.Context()); err != nil {
+		sendProjectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusAccepted, "Projection replay requested", nil)
+}
+
+func (s *ProjectionHandler) Views(c *gin.Context) {
+	var req request.CatalogViewRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	views, err := s.service.Views(c.Request.Context(), req)
+	if err != nil {
+		sendProjectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get catalog views successfully", views)
+}
+
+func (s *ProjectionHandler) History(c *gin.Context) {
+	var req request.StreamHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	history, err := s.service.History(c.Request.Context(), c.Param("aggregateType"), c.Param("id"), req)
+	if err != nil {
+		sendProjectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get stream history successfully", history)
+}
+
+func sendProjectionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEventStoreDisabled):
+		helper.SendError(c, http.StatusServiceUnavailable, err, helper.ErrInvalidOperation)
+	case errors.Is(err, service.ErrInvalidStream):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
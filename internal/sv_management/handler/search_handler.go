@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	service service.SvSearchService
+}
+
+func NewSearchHandler(service service.SvSearchService) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+func (s *SearchHandler) Search(c *gin.Context) {
+	var req request.SearchServicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := s.service.Search(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Search services successfully", res)
+}
+
+func (s *SearchHandler) SearchCatalog(c *gin.Context) {
+	var req request.SearchCatalogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := s.service.SearchCatalog(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Search services successfully", res)
+}
+
+func (s *SearchHandler) Reindex(c *gin.Context) {
+	if err := s.service.Reindex(c.Request.Context()); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Reindex services successfully", nil)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"services-management/helper"
 	"services-management/internal/sv_management/dto/request"
@@ -27,9 +28,79 @@ func (s *ServiceGroupHandler) Upload(c *gin.Context) {
 	}
 
 	err := s.service.UploadServiceGroup(c.Request.Context(), req)
+	if errors.Is(err, service.ErrAssetNotFound) || errors.Is(err, service.ErrUnsupportedLocale) ||
+		errors.Is(err, service.ErrParentGroupNotFound) || errors.Is(err, service.ErrParentGroupOrganization) {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	if errors.Is(err, service.ErrGroupTreeLocked) {
+		helper.SendError(c, http.StatusConflict, err, helper.ErrInvalidOperation)
+		return
+	}
 	if err != nil {
 		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 	helper.SendSuccess(c, http.StatusOK, "Upload service group successfully", nil)
 }
+
+func (s *ServiceGroupHandler) Move(c *gin.Context) {
+	var req request.MoveServiceGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	err := s.service.MoveServiceGroup(c.Request.Context(), c.Param("id"), req)
+	switch {
+	case errors.Is(err, service.ErrServiceGroupNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	case errors.Is(err, service.ErrParentGroupNotFound), errors.Is(err, service.ErrGroupCycle),
+		errors.Is(err, service.ErrParentGroupOrganization):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidOperation)
+		return
+	case errors.Is(err, service.ErrGroupTreeLocked):
+		helper.SendError(c, http.StatusConflict, err, helper.ErrInvalidOperation)
+		return
+	case err != nil:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Move service group successfully", nil)
+}
+
+func (s *ServiceGroupHandler) Reorder(c *gin.Context) {
+	var req request.ReorderServiceGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	err := s.service.ReorderServiceGroups(c.Request.Context(), req)
+	if errors.Is(err, service.ErrInvalidReorder) {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidOperation)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Reorder service groups successfully", nil)
+}
+
+func (s *ServiceGroupHandler) Delete(c *gin.Context) {
+	err := s.service.DeleteServiceGroup(c.Request.Context(), c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrServiceGroupNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	case errors.Is(err, service.ErrServiceGroupNotEmpty):
+		helper.SendError(c, http.StatusConflict, err, helper.ErrInvalidOperation)
+		return
+	case err != nil:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete service group successfully", nil)
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"services-management/helper"
 	"services-management/internal/sv_management/dto/request"
 	service "services-management/internal/sv_management/services"
+	"services-management/pkg/i18n"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,6 +29,11 @@ func (s *ServiceHandler) Upload(c *gin.Context) {
 	}
 
 	err := s.service.UploadService(c.Request.Context(), req)
+	if errors.Is(err, service.ErrAssetNotFound) || errors.Is(err, service.ErrUnsupportedLocale) ||
+		errors.Is(err, service.ErrInvalidUrlTemplate) || errors.Is(err, service.ErrMissingEnvironmentUrl) {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
 	if err != nil {
 		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
@@ -35,10 +42,147 @@ func (s *ServiceHandler) Upload(c *gin.Context) {
 }
 
 func (s *ServiceHandler) GetServices(c *gin.Context) {
-	services, err := s.service.GetServices(c.Request.Context())
+	var req request.GetServicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	// không có tham số nào thì giữ dạng mảng toàn bộ group cho client cũ
+	if req.IsLegacy() {
+		groups, err := s.service.GetAllServices(c.Request.Context(), req)
+		if err != nil {
+			helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+			return
+		}
+		helper.SendSuccess(c, http.StatusOK, "Get services successfully", groups)
+		return
+	}
+
+	// shape=tree trả về cây group lồng nhau
+	if req.Shape == "tree" {
+		tree, err := s.service.GetServicesTree(c.Request.Context(), req)
+		if err != nil {
+			helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+			return
+		}
+		helper.SendSuccess(c, http.StatusOK, "Get services successfully", tree)
+		return
+	}
+
+	services, err := s.service.GetServices(c.Request.Context(), req)
 	if err != nil {
 		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 	helper.SendSuccess(c, http.StatusOK, "Get services successfully", services)
 }
+
+func (s *ServiceHandler) ListServices(c *gin.Context) {
+	var req request.GetServicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	services, err := s.service.ListServices(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "List services successfully", services)
+}
+
+// requestLocales ưu tiên query lang, không có thì đọc Accept-Language
+func requestLocales(c *gin.Context, lang string) []string {
+	if lang != "" {
+		return []string{lang}
+	}
+	return i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+}
+
+func (s *ServiceHandler) Delete(c *gin.Context) {
+	err := s.service.DeleteService(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, service.ErrServiceNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete service successfully", nil)
+}
+
+func (s *ServiceHandler) ListTags(c *gin.Context) {
+	var req request.ListTagsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	tags, err := s.service.ListTags(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get tags successfully", tags)
+}
+
+func (s *ServiceHandler) SetTags(c *gin.Context) {
+	var req request.SetServiceTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	if err := s.service.SetServiceTags(c.Request.Context(), c.Param("id"), req); err != nil {
+		sendTagError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Update service tags successfully", nil)
+}
+
+func (s *ServiceHandler) RenameTag(c *gin.Context) {
+	var req request.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	if err := s.service.RenameTag(c.Request.Context(), c.Param("tag"), req); err != nil {
+		sendTagError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Rename tag successfully", nil)
+}
+
+func (s *ServiceHandler) DeleteTag(c *gin.Context) {
+	if err := s.service.DeleteTag(c.Request.Context(), c.Param("tag")); err != nil {
+		sendTagError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete tag successfully", nil)
+}
+
+func sendTagError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrServiceNotFound), errors.Is(err, service.ErrTagNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrInvalidTag):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
+
+func (s *ServiceHandler) CacheStats(c *gin.Context) {
+	helper.SendSuccess(c, http.StatusOK, "Get catalog cache stats successfully", s.service.CatalogCacheStats())
+}
+
+func (s *ServiceHandler) InvalidateCache(c *gin.Context) {
+	s.service.InvalidateCatalogCache(c.Request.Context())
+	helper.SendSuccess(c, http.StatusOK, "Catalog cache invalidated", nil)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SmartCollectionHandler struct {
+	service service.SmartCollectionService
+}
+
+func NewSmartCollectionHandler(service service.SmartCollectionService) *SmartCollectionHandler {
+	return &SmartCollectionHandler{
+		service: service,
+	}
+}
+
+func (s *SmartCollectionHandler) Create(c *gin.Context) {
+	var req request.SmartCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	collection, err := s.service.Create(c.Request.Context(), req)
+	if err != nil {
+		sendCollectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Create smart collection successfully", collection)
+}
+
+func (s *SmartCollectionHandler) Update(c *gin.Context) {
+	var req request.SmartCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	collection, err := s.service.Update(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendCollectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Update smart collection successfully", collection)
+}
+
+func (s *SmartCollectionHandler) Delete(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		sendCollectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete smart collection successfully", nil)
+}
+
+func (s *SmartCollectionHandler) Get(c *gin.Context) {
+	collection, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		sendCollectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get smart collection successfully", collection)
+}
+
+func (s *SmartCollectionHandler) List(c *gin.Context) {
+	var req request.ListSmartCollectionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	collections, err := s.service.List(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get smart collections successfully", collections)
+}
+
+func (s *SmartCollectionHandler) Services(c *gin.Context) {
+	var req request.CollectionServicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	services, err := s.service.Services(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendCollectionError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get smart collection services successfully", services)
+}
+
+func sendCollectionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCollectionNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrEmptyCollectionQuery), errors.Is(err, service.ErrUnsupportedLocale):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SSOHandler struct {
+	service service.SSOService
+}
+
+func NewSSOHandler(service service.SSOService) *SSOHandler {
+	return &SSOHandler{
+		service: service,
+	}
+}
+
+func (s *SSOHandler) Configure(c *gin.Context) {
+	var req request.SSOConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	cfg, err := s.service.Configure(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendSSOError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Configure sso successfully", cfg)
+}
+
+func (s *SSOHandler) GetConfig(c *gin.Context) {
+	cfg, err := s.service.GetConfig(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		sendSSOError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get sso config successfully", cfg)
+}
+
+func (s *SSOHandler) Disable(c *gin.Context) {
+	if err := s.service.Disable(c.Request.Context(), c.Param("id")); err != nil {
+		sendSSOError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Disable sso successfully", nil)
+}
+
+func (s *SSOHandler) Verify(c *gin.Context) {
+	var req request.VerifySSOTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := s.service.Verify(c.Request.Context(), req)
+	if errors.Is(err, service.ErrInvalidSSOToken) || errors.Is(err, service.ErrSSOTokenReplayed) {
+		helper.SendError(c, http.StatusUnauthorized, err, helper.ErrUnauthorized)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Verify sso token successfully", res)
+}
+
+func sendSSOError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrServiceNotFound), errors.Is(err, service.ErrSSONotConfigured):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrInvalidSSOConfig):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatusPageHandler struct {
+	service service.StatusPageService
+}
+
+func NewStatusPageHandler(service service.StatusPageService) *StatusPageHandler {
+	return &StatusPageHandler{
+		service: service,
+	}
+}
+
+// GetStatus JSON feed, cho phép portal khác gọi trực tiếp từ trình duyệt
+func (s *StatusPageHandler) GetStatus(c *gin.Context) {
+	var req request.StatusPageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	page, err := s.service.GetStatusPage(c.Request.Context(), req)
+	if err != nil {
+		sendStatusPageError(c, err)
+		return
+	}
+
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("Cache-Control", "public, max-age=60")
+	helper.SendSuccess(c, http.StatusOK, "Get status page successfully", page)
+}
+
+// GetStatusEmbed HTML để nhúng bằng iframe
+func (s *StatusPageHandler) GetStatusEmbed(c *gin.Context) {
+	var req request.StatusPageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	page, err := s.service.GetStatusPage(c.Request.Context(), req)
+	if err != nil {
+		sendStatusPageError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := statusPageTemplate.Execute(&buf, page); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=60")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+func sendStatusPageError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrOrganizationNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+}
+
+var statusPageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"pct": func(v *float64) string {
+		if v == nil {
+			return "–"
+		}
+		return formatPercent(*v)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Service status</title>
+<style>
+body{font-family:system-ui,sans-serif;margin:0;padding:12px;color:#222;font-size:14px}
+h2{font-size:15px;margin:16px 0 6px}
+table{width:100%;border-collapse:collapse}
+td,th{padding:4px 6px;border-bottom:1px solid #eee;text-align:left}
+th{font-weight:600;color:#666}
+.banner{padding:8px 12px;border-radius:6px;font-weight:600}
+.operational,.up{color:#1a7f37}.degraded{color:#9a6700}.outage,.down{color:#cf222e}.unknown{color:#888}
+.banner.operational{background:#dafbe1}.banner.degraded{background:#fff8c5}.banner.outage{background:#ffebe9}
+</style>
+</head>
+<body>
+<div class="banner {{.Status}}">{{.Status}}</div>
+{{range .Groups}}
+<h2>{{.Group.Title}}</h2>
+<table>
+<tr><th>Service</th><th>Status</th><th>24h</th><th>7d</th><th>30d</th><th>p95</th></tr>
+{{range .Services}}<tr>
+<td>{{.Title}}</td><td class="{{.Status}}">{{.Status}}</td>
+<td>{{pct .Uptime.Last24h}}</td><td>{{pct .Uptime.Last7d}}</td><td>{{pct .Uptime.Last30d}}</td>
+<td>{{if .ResponseTime.P95}}{{.ResponseTime.P95}} ms{{else}}–{{end}}</td>
+</tr>{{end}}
+</table>
+{{end}}
+<p style="color:#888;font-size:12px">{{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</p>
+</body>
+</html>`))
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64) + "%"
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TranslationHandler struct {
+	service service.TranslationService
+}
+
+func NewTranslationHandler(service service.TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		service: service,
+	}
+}
+
+func (s *TranslationHandler) SetServiceTranslation(c *gin.Context) {
+	var req request.TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	err := s.service.SetServiceTranslation(c.Request.Context(), c.Param("id"), c.Param("locale"), req)
+	if sendTranslationError(c, err) {
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Set service translation successfully", nil)
+}
+
+func (s *TranslationHandler) DeleteServiceTranslation(c *gin.Context) {
+	err := s.service.DeleteServiceTranslation(c.Request.Context(), c.Param("id"), c.Param("locale"))
+	if sendTranslationError(c, err) {
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete service translation successfully", nil)
+}
+
+func (s *TranslationHandler) SetGroupTranslation(c *gin.Context) {
+	var req request.TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	err := s.service.SetGroupTranslation(c.Request.Context(), c.Param("id"), c.Param("locale"), req)
+	if sendTranslationError(c, err) {
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Set service group translation successfully", nil)
+}
+
+func (s *TranslationHandler) DeleteGroupTranslation(c *gin.Context) {
+	err := s.service.DeleteGroupTranslation(c.Request.Context(), c.Param("id"), c.Param("locale"))
+	if sendTranslationError(c, err) {
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete service group translation successfully", nil)
+}
+
+func (s *TranslationHandler) GetMissing(c *gin.Context) {
+	res, err := s.service.GetMissing(c.Request.Context(), c.Query("locale"))
+	if sendTranslationError(c, err) {
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get missing translations successfully", res)
+}
+
+// sendTranslationError trả true nếu đã gửi response lỗi
+func sendTranslationError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, service.ErrUnsupportedLocale):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	case errors.Is(err, service.ErrServiceNotFound), errors.Is(err, service.ErrServiceGroupNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+	return true
+}
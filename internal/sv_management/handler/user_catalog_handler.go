@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserCatalogHandler struct {
+	service service.UserCatalogService
+}
+
+func NewUserCatalogHandler(service service.UserCatalogService) *UserCatalogHandler {
+	return &UserCatalogHandler{
+		service: service,
+	}
+}
+
+func (s *UserCatalogHandler) GetCatalog(c *gin.Context) {
+	var req request.GetCatalogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	catalog, err := s.service.GetCatalog(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get catalog successfully", catalog)
+}
+
+func (s *UserCatalogHandler) GetFavorites(c *gin.Context) {
+	var req request.GetCatalogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.Locales = requestLocales(c, req.Lang)
+
+	favorites, err := s.service.GetFavorites(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get favorites successfully", favorites)
+}
+
+func (s *UserCatalogHandler) AddFavorite(c *gin.Context) {
+	err := s.service.AddFavorite(c.Request.Context(), c.Param("serviceId"))
+	if errors.Is(err, service.ErrServiceNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Add favorite successfully", nil)
+}
+
+func (s *UserCatalogHandler) RemoveFavorite(c *gin.Context) {
+	if err := s.service.RemoveFavorite(c.Request.Context(), c.Param("serviceId")); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Remove favorite successfully", nil)
+}
+
+func (s *UserCatalogHandler) ReorderFavorites(c *gin.Context) {
+	var req request.ReorderFavoritesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	err := s.service.ReorderFavorites(c.Request.Context(), req)
+	if errors.Is(err, service.ErrInvalidFavorites) {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidOperation)
+		return
+	}
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Reorder favorites successfully", nil)
+}
+
+func (s *UserCatalogHandler) SetOrdering(c *gin.Context) {
+	var req request.SetOrderingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	if err := s.service.SetOrdering(c.Request.Context(), req); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Set ordering successfully", nil)
+}
+
+func (s *UserCatalogHandler) ResetOrdering(c *gin.Context) {
+	if err := s.service.ResetOrdering(c.Request.Context()); err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Reset ordering successfully", nil)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"services-management/helper"
+	"services-management/internal/sv_management/dto/request"
+	service "services-management/internal/sv_management/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+func (s *WebhookHandler) Create(c *gin.Context) {
+	var req request.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	webhook, err := s.service.Create(c.Request.Context(), req)
+	if err != nil {
+		sendWebhookError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Create webhook successfully", webhook)
+}
+
+func (s *WebhookHandler) Update(c *gin.Context) {
+	var req request.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	webhook, err := s.service.Update(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendWebhookError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Update webhook successfully", webhook)
+}
+
+func (s *WebhookHandler) Delete(c *gin.Context) {
+	if err := s.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		sendWebhookError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delete webhook successfully", nil)
+}
+
+func (s *WebhookHandler) Get(c *gin.Context) {
+	webhook, err := s.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		sendWebhookError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get webhook successfully", webhook)
+}
+
+func (s *WebhookHandler) List(c *gin.Context) {
+	webhooks, err := s.service.List(c.Request.Context())
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get webhooks successfully", webhooks)
+}
+
+func (s *WebhookHandler) Deliveries(c *gin.Context) {
+	var req request.ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	deliveries, err := s.service.Deliveries(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		sendWebhookError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get webhook deliveries successfully", deliveries)
+}
+
+func (s *WebhookHandler) DeadLetters(c *gin.Context) {
+	var req request.ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	deliveries, err := s.service.DeadLetters(c.Request.Context(), req)
+	if err != nil {
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Get dead-letter deliveries successfully", deliveries)
+}
+
+func (s *WebhookHandler) Redeliver(c *gin.Context) {
+	if err := s.service.Redeliver(c.Request.Context(), c.Param("deliveryId")); err != nil {
+		sendWebhookError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Delivery queued for retry", nil)
+}
+
+func sendWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrDeadLetterNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFound)
+	case errors.Is(err, service.ErrInvalidWebhook):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,212 @@
+package health
+
+import (
+	"context"
+	"net/url"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"services-management/pkg/constants"
+	"services-management/pkg/urltemplate"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultInterval    = 5 * time.Minute
+	defaultConcurrency = 8
+	writeTimeout       = 10 * time.Second
+)
+
+type Options struct {
+	Interval    time.Duration
+	Timeout     time.Duration
+	Method      string
+	Concurrency int
+	// Environment môi trường có url được probe
+	Environment string
+	// OnStatusChange gọi sau khi lưu kết quả probe có status khác lần trước
+	OnStatusChange func(ctx context.Context, svc *model.Service, state model.HealthState)
+	// OnSaved gọi một lần sau mỗi lượt probe (hoặc CheckService) có lưu trạng thái, dùng để vô hiệu cache catalog
+	OnSaved func(ctx context.Context)
+}
+
+// Job định kỳ probe url của mọi service đang active
+type Job struct {
+	serviceRepo repository.ServiceRepository
+	historyRepo repository.HealthCheckRepository
+	prober      *Prober
+	opts        Options
+}
+
+func NewJob(serviceRepo repository.ServiceRepository, historyRepo repository.HealthCheckRepository, prober *Prober, opts Options) *Job {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	return &Job{
+		serviceRepo: serviceRepo,
+		historyRepo: historyRepo,
+		prober:      prober,
+		opts:        opts,
+	}
+}
+
+// Start chạy job tới khi ctx bị huỷ
+func (j *Job) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(j.opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			if err := j.Run(ctx); err != nil {
+				logger.WriteLogEx("error", "health probe failed", map[string]any{
+					"error": err.Error(),
+				})
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Run probe tất cả service một lượt, tối đa Concurrency request cùng lúc
+func (j *Job) Run(ctx context.Context) error {
+	services, _, err := j.serviceRepo.Find(ctx, repository.ServiceFilter{
+		Status: string(constants.ServiceStatusActive),
+	}, repository.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	sem := make(chan struct{}, j.opts.Concurrency)
+	var wg sync.WaitGroup
+	var saved atomic.Bool
+	for _, svc := range services {
+		if _, ok := j.checkFor(svc); !ok {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(svc *model.Service) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, ok := j.check(ctx, svc); ok {
+				saved.Store(true)
+			}
+		}(svc)
+	}
+	wg.Wait()
+	if saved.Load() {
+		j.saved(ctx)
+	}
+	return nil
+}
+
+// CheckService probe một service, lưu lịch sử và trạng thái mới nhất.
+// Trả về nil nếu service không probe được (tắt probe, url có placeholder hoặc không phải http)
+func (j *Job) CheckService(ctx context.Context, svc *model.Service) *model.HealthCheck {
+	history, saved := j.check(ctx, svc)
+	if saved {
+		j.saved(ctx)
+	}
+	return history
+}
+
+// check probe và lưu kết quả, saved cho biết trạng thái mới nhất đã được ghi vào service
+func (j *Job) check(ctx context.Context, svc *model.Service) (*model.HealthCheck, bool) {
+	check, ok := j.checkFor(svc)
+	if !ok {
+		return nil, false
+	}
+
+	result := j.prober.Probe(ctx, check)
+	checkedAt := time.Now()
+	state := result.State(checkedAt)
+	history := &model.HealthCheck{
+		ServiceID:  svc.ID.Hex(),
+		Url:        check.Url,
+		Status:     state.Status,
+		StatusCode: state.StatusCode,
+		LatencyMs:  state.LatencyMs,
+		Error:      state.Error,
+		CheckedAt:  checkedAt,
+	}
+
+	// vẫn lưu kết quả khi ctx của lượt probe đã hết hạn
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+	if err := j.historyRepo.Insert(writeCtx, history); err != nil {
+		logWriteError(svc, err)
+	}
+	if err := j.serviceRepo.SetHealth(writeCtx, svc.ID.Hex(), state); err != nil {
+		logWriteError(svc, err)
+		return history, false
+	}
+	if j.opts.OnStatusChange != nil && (svc.Health == nil || svc.Health.Status != state.Status) {
+		j.opts.OnStatusChange(writeCtx, svc, state)
+	}
+	return history, true
+}
+
+func (j *Job) saved(ctx context.Context) {
+	if j.opts.OnSaved == nil {
+		return
+	}
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+	j.opts.OnSaved(writeCtx)
+}
+
+func (j *Job) checkFor(svc *model.Service) (Check, bool) {
+	cfg := svc.HealthCheck
+	if cfg == nil {
+		cfg = &model.HealthCheckConfig{}
+	}
+	if cfg.Disabled {
+		return Check{}, false
+	}
+
+	target := cfg.ProbeUrl
+	if target == "" {
+		target = svc.UrlFor(j.opts.Environment)
+	}
+	// url render theo user không probe được, admin cần đặt probe_url
+	if urltemplate.HasPlaceholders(target) {
+		return Check{}, false
+	}
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Check{}, false
+	}
+
+	check := Check{
+		Url:            target,
+		Method:         strings.ToUpper(cfg.Method),
+		Timeout:        j.opts.Timeout,
+		ExpectedStatus: cfg.ExpectedStatus,
+		Keyword:        cfg.Keyword,
+	}
+	if check.Method == "" {
+		check.Method = strings.ToUpper(j.opts.Method)
+	}
+	if cfg.TimeoutMs > 0 {
+		check.Timeout = time.Duration(cfg.TimeoutMs) * time.Millisecond
+	}
+	return check, true
+}
+
+func logWriteError(svc *model.Service, err error) {
+	logger.WriteLogEx("error", "save health check failed", map[string]any{
+		"service_id": svc.ID.Hex(),
+		"error":      err.Error(),
+	})
+}
@@ -0,0 +1,124 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"services-management/internal/sv_management/model"
+	"time"
+)
+
+const (
+	DefaultTimeout = 5 * time.Second
+	// maxBodyBytes chỉ đọc tối đa 1MB khi tìm keyword
+	maxBodyBytes = 1 << 20
+)
+
+// Check cấu hình đã gộp mặc định của một lần probe
+type Check struct {
+	Url            string
+	Method         string
+	Timeout        time.Duration
+	ExpectedStatus int
+	Keyword        string
+}
+
+type Result struct {
+	Up         bool
+	StatusCode int
+	Latency    time.Duration
+	Error      string
+}
+
+// Prober gửi request tới url của service. Client truyền từ ngoài để có thể thay transport
+type Prober struct {
+	client *http.Client
+}
+
+func NewProber(client *http.Client) *Prober {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &Prober{client: client}
+}
+
+func (p *Prober) Probe(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	// keyword cần body nên luôn dùng GET
+	if check.Keyword != "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, check.Url, nil)
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+	req.Header.Set("User-Agent", "services-management-health-prober")
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Result{Latency: time.Since(start), Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	result := Result{StatusCode: resp.StatusCode}
+	if !statusOK(resp.StatusCode, check.ExpectedStatus) {
+		result.Latency = time.Since(start)
+		result.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		return result
+	}
+
+	if check.Keyword != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+		result.Latency = time.Since(start)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if !bytes.Contains(body, []byte(check.Keyword)) {
+			result.Error = fmt.Sprintf("keyword %q not found", check.Keyword)
+			return result
+		}
+	} else {
+		result.Latency = time.Since(start)
+	}
+
+	result.Up = true
+	return result
+}
+
+// statusOK expected 0 thì chấp nhận 2xx và 3xx
+func statusOK(status, expected int) bool {
+	if expected != 0 {
+		return status == expected
+	}
+	return status >= 200 && status < 400
+}
+
+// State trạng thái lưu lên service từ kết quả probe
+func (r Result) State(checkedAt time.Time) model.HealthState {
+	status := model.HealthStatusDown
+	if r.Up {
+		status = model.HealthStatusUp
+	}
+	return model.HealthState{
+		Status:      status,
+		LastChecked: checkedAt,
+		StatusCode:  r.StatusCode,
+		LatencyMs:   r.Latency.Milliseconds(),
+		Error:       r.Error,
+	}
+}
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbeStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected int
+		up       bool
+	}{
+		{name: "2xx mặc định là up", status: http.StatusOK, up: true},
+		{name: "3xx mặc định là up", status: http.StatusNotModified, up: true},
+		{name: "4xx mặc định là down", status: http.StatusNotFound, up: false},
+		{name: "5xx mặc định là down", status: http.StatusInternalServerError, up: false},
+		{name: "đúng expected status", status: http.StatusUnauthorized, expected: http.StatusUnauthorized, up: true},
+		{name: "khác expected status", status: http.StatusOK, expected: http.StatusNoContent, up: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			result := NewProber(nil).Probe(context.Background(), Check{Url: server.URL, ExpectedStatus: tt.expected})
+			if result.Up != tt.up {
+				t.Fatalf("Up = %v, want %v (error %q)", result.Up, tt.up, result.Error)
+			}
+			if result.StatusCode != tt.status {
+				t.Fatalf("StatusCode = %d, want %d", result.StatusCode, tt.status)
+			}
+			if !tt.up && result.Error == "" {
+				t.Fatal("Error is empty for a down result")
+			}
+		})
+	}
+}
+
+func TestProbeKeyword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	prober := NewProber(nil)
+	if result := prober.Probe(context.Background(), Check{Url: server.URL, Keyword: "healthy"}); !result.Up {
+		t.Fatalf("keyword found: Up = false (error %q)", result.Error)
+	}
+
+	result := prober.Probe(context.Background(), Check{Url: server.URL, Keyword: "maintenance"})
+	if result.Up {
+		t.Fatal("keyword not found: Up = true")
+	}
+	if !strings.Contains(result.Error, "maintenance") {
+		t.Fatalf("Error = %q, want keyword in message", result.Error)
+	}
+}
+
+func TestProbeKeywordUsesGet(t *testing.T) {
+	methods := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods <- r.Method
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	prober := NewProber(nil)
+	prober.Probe(context.Background(), Check{Url: server.URL, Method: http.MethodHead})
+	if got := <-methods; got != http.MethodHead {
+		t.Fatalf("method without keyword = %s, want HEAD", got)
+	}
+
+	prober.Probe(context.Background(), Check{Url: server.URL, Method: http.MethodHead, Keyword: "ok"})
+	if got := <-methods; got != http.MethodGet {
+		t.Fatalf("method with keyword = %s, want GET", got)
+	}
+}
+
+func TestProbeTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	start := time.Now()
+	result := NewProber(nil).Probe(context.Background(), Check{Url: server.URL, Timeout: 50 * time.Millisecond})
+	if result.Up {
+		t.Fatal("Up = true for a request past the timeout")
+	}
+	if result.Error == "" {
+		t.Fatal("Error is empty for a timed out request")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("probe took %v, want it to stop at the timeout", elapsed)
+	}
+}
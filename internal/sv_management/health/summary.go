@@ -0,0 +1,108 @@
+package health
+
+import (
+	"services-management/internal/sv_management/model"
+	"sort"
+	"time"
+)
+
+const maxIncidents = 10
+
+// Windows các khoảng tính uptime
+var Windows = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+type Incident struct {
+	Start time.Time
+	End   *time.Time // nil là sự cố đang diễn ra
+}
+
+// Summary số liệu status page của một service tính từ lịch sử probe
+type Summary struct {
+	Uptime    []*float64 // theo thứ tự Windows, nil khi không có lần probe nào trong khoảng
+	P50       int64      // ms, tính trên các lần probe thành công trong 24h
+	P95       int64
+	P99       int64
+	Incidents []Incident // mới nhất trước
+}
+
+// Summarizer gom lịch sử probe của một service, check phải đưa vào theo checked_at tăng dần
+type Summarizer struct {
+	now       time.Time
+	up        []int
+	total     []int
+	latencies []int64
+	incidents []Incident
+	open      *Incident
+}
+
+func NewSummarizer(now time.Time) *Summarizer {
+	return &Summarizer{
+		now:   now,
+		up:    make([]int, len(Windows)),
+		total: make([]int, len(Windows)),
+	}
+}
+
+func (s *Summarizer) Add(check *model.HealthCheck) {
+	age := s.now.Sub(check.CheckedAt)
+	up := check.Status == model.HealthStatusUp
+	for i, window := range Windows {
+		if age > window {
+			continue
+		}
+		s.total[i]++
+		if up {
+			s.up[i]++
+		}
+	}
+	if up && age <= Windows[0] {
+		s.latencies = append(s.latencies, check.LatencyMs)
+	}
+
+	// sự cố là chuỗi probe down liên tiếp, kết thúc ở lần up đầu tiên sau đó
+	switch {
+	case !up && s.open == nil:
+		s.open = &Incident{Start: check.CheckedAt}
+	case up && s.open != nil:
+		end := check.CheckedAt
+		s.open.End = &end
+		s.incidents = append(s.incidents, *s.open)
+		s.open = nil
+	}
+}
+
+func (s *Summarizer) Summary() Summary {
+	summary := Summary{Uptime: make([]*float64, len(Windows))}
+	for i := range Windows {
+		if s.total[i] > 0 {
+			pct := float64(s.up[i]) * 100 / float64(s.total[i])
+			summary.Uptime[i] = &pct
+		}
+	}
+
+	sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+	summary.P50 = percentile(s.latencies, 50)
+	summary.P95 = percentile(s.latencies, 95)
+	summary.P99 = percentile(s.latencies, 99)
+
+	incidents := s.incidents
+	if s.open != nil {
+		incidents = append(incidents, *s.open)
+	}
+	for i := len(incidents) - 1; i >= 0 && len(summary.Incidents) < maxIncidents; i-- {
+		summary.Incidents = append(summary.Incidents, incidents[i])
+	}
+	return summary
+}
+
+// percentile nearest-rank trên mảng đã sắp xếp
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
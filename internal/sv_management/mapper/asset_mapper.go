@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"fmt"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/model"
+)
+
+func MapAssetToAssetResDto(asset *model.Asset, publicUrl string) *response.AssetResDto {
+	url := publicUrl + "/" + asset.ID.Hex()
+
+	variants := make([]response.AssetVariantResDto, 0, len(asset.Variants))
+	for _, v := range asset.Variants {
+		variants = append(variants, response.AssetVariantResDto{
+			Size:   v.Size,
+			Width:  v.Width,
+			Height: v.Height,
+			Url:    fmt.Sprintf("%s?size=%d", url, v.Size),
+		})
+	}
+
+	return &response.AssetResDto{
+		ID:          asset.ID.Hex(),
+		Url:         url,
+		FileName:    asset.FileName,
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+		Width:       asset.Width,
+		Height:      asset.Height,
+		Variants:    variants,
+	}
+}
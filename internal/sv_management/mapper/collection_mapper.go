@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/model"
+	"services-management/pkg/constants"
+)
+
+func MapSmartCollectionResDto(c *model.SmartCollection) *response.SmartCollectionResDto {
+	return &response.SmartCollectionResDto{
+		ID:             c.ID.Hex(),
+		OrganizationID: c.OrganizationID,
+		Title:          c.Title,
+		Order:          c.Order,
+		Limit:          c.Limit,
+		Query: response.CollectionQueryResDto{
+			Tags:            c.Query.Tags,
+			MatchAllTags:    c.Query.MatchAllTags,
+			Roles:           c.Query.Roles,
+			OrganizationIDs: c.Query.OrganizationIDs,
+		},
+		Translations: MapTranslationsToResDto(c.Translations),
+		CreatedBy:    c.CreatedBy,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+// MapCollectionGroupResponse collection hiển thị trong catalog như một group
+func MapCollectionGroupResponse(c *model.SmartCollection, locales ...string) response.ServiceGroupResponse {
+	return response.ServiceGroupResponse{
+		ID:    c.ID.Hex(),
+		Title: localize(c.Title, c.Translations, locales, translationTitle),
+		Order: c.Order,
+		Kind:  constants.CollectionGroupKind,
+	}
+}
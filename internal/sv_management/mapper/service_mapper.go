@@ -1,40 +1,129 @@
 package mapper
 
 import (
+	"services-management/internal/sv_management/dto/request"
 	"services-management/internal/sv_management/dto/response"
 	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/search"
+	"services-management/pkg/i18n"
+	"strings"
 )
 
-func MapServiceToServiceResDto(service model.Service) *response.ServiceResDto {
-	return &response.ServiceResDto{
-		ID:    service.ID.Hex(),
-		Title: service.Title,
-		Order: service.Order,
-		Url:   service.Url,
+// MapServiceToServiceResDto locales là chuỗi fallback dùng để chọn title/description theo ngôn ngữ
+func MapServiceToServiceResDto(service model.Service, locales ...string) *response.ServiceResDto {
+	res := &response.ServiceResDto{
+		ID:             service.ID.Hex(),
+		OrganizationID: service.OrganizationID,
+		Title:          localize(service.Title, service.Translations, locales, translationTitle),
+		Order:          service.Order,
+		Url:            service.Url,
+		Urls:           service.Urls,
+		Description:    localize(service.Description, service.Translations, locales, translationDescription),
+		Icon:           MapIconToIconResDto(service.Icon),
+		Color:          service.Color,
+		LaunchMode:     service.LaunchMode,
+		Tags:           service.Tags,
+		Status:         service.Status,
+		Roles:          service.Roles,
+		Translations:   MapTranslationsToResDto(service.Translations),
+		Ownership:      MapOwnershipResDto(service.Ownership),
+		DependsOn:      service.DependsOn,
 	}
+	if service.Health != nil {
+		res.HealthStatus = service.Health.Status
+		res.LastChecked = &service.Health.LastChecked
+	}
+	return res
+}
+
+func MapIconToIconResDto(icon *model.Icon) *response.IconResDto {
+	if icon == nil {
+		return nil
+	}
+	return &response.IconResDto{
+		ImageID:  icon.ImageID,
+		ImageKey: icon.ImageKey,
+		ImageUrl: icon.ImageUrl,
+	}
+}
+
+func MapIconRequestToIcon(req *request.IconRequest) *model.Icon {
+	if req == nil {
+		return nil
+	}
+	return &model.Icon{
+		ImageID:  req.ImageID,
+		ImageKey: req.ImageKey,
+		ImageUrl: req.ImageUrl,
+	}
+}
+
+func MapTranslationsToResDto(translations map[string]model.Translation) map[string]response.TranslationResDto {
+	if len(translations) == 0 {
+		return nil
+	}
+	result := make(map[string]response.TranslationResDto, len(translations))
+	for locale, t := range translations {
+		result[locale] = response.TranslationResDto{
+			Title:       t.Title,
+			Description: t.Description,
+		}
+	}
+	return result
+}
+
+func MapTranslationRequests(reqs map[string]request.TranslationRequest) map[string]model.Translation {
+	if len(reqs) == 0 {
+		return nil
+	}
+	result := make(map[string]model.Translation, len(reqs))
+	for locale, t := range reqs {
+		result[i18n.Normalize(locale)] = model.Translation{
+			Title:       strings.TrimSpace(t.Title),
+			Description: strings.TrimSpace(t.Description),
+		}
+	}
+	return result
 }
 
-func MapServicesResponse(groups []*model.ServiceGroup, services []*model.Service) []*response.ServicesResponse {
+func translationTitle(t model.Translation) string {
+	return t.Title
+}
+
+func translationDescription(t model.Translation) string {
+	return t.Description
+}
+
+// localize lấy giá trị của locale đầu tiên trong chuỗi có bản dịch, không có thì dùng giá trị gốc
+func localize(base string, translations map[string]model.Translation, locales []string, field func(model.Translation) string) string {
+	for _, locale := range locales {
+		if t, ok := translations[locale]; ok && field(t) != "" {
+			return field(t)
+		}
+	}
+	return base
+}
+
+func MapServicesToServiceResDtos(services []*model.Service, locales ...string) []*response.ServiceResDto {
+	result := make([]*response.ServiceResDto, 0, len(services))
+	for _, svc := range services {
+		result = append(result, MapServiceToServiceResDto(*svc, locales...))
+	}
+	return result
+}
+
+func MapServicesResponse(groups []*model.ServiceGroup, services []*model.Service, locales ...string) []*response.ServicesResponse {
 	// Gom service theo group
 	serviceMap := make(map[string][]response.ServiceResDto)
 	for _, svc := range services {
-		serviceMap[svc.GroupID] = append(serviceMap[svc.GroupID], response.ServiceResDto{
-			ID:    svc.ID.Hex(),
-			Title: svc.Title,
-			Url:   svc.Url,
-			Order: svc.Order,
-		})
+		serviceMap[svc.GroupID] = append(serviceMap[svc.GroupID], *MapServiceToServiceResDto(*svc, locales...))
 	}
 
 	// Build response
 	var result []*response.ServicesResponse
 	for _, g := range groups {
 		res := &response.ServicesResponse{
-			Group: response.ServiceGroupResponse{
-				ID:    g.ID.Hex(),
-				Title: g.Title,
-				Order: g.Order,
-			},
+			Group:    mapGroupResponse(g, locales),
 			Services: serviceMap[g.ID.Hex()],
 		}
 		result = append(result, res)
@@ -42,3 +131,133 @@ func MapServicesResponse(groups []*model.ServiceGroup, services []*model.Service
 
 	return result
 }
+
+// MapSearchResponse giữ nguyên thứ tự hits, bỏ qua hit không còn service tương ứng
+func MapSearchResponse(query string, hits []search.Hit, services []*model.Service, groupTitles map[string]string) *response.SearchServicesResponse {
+	serviceMap := make(map[string]*model.Service, len(services))
+	for _, svc := range services {
+		serviceMap[svc.ID.Hex()] = svc
+	}
+
+	items := make([]*response.SearchServiceResDto, 0, len(hits))
+	for _, h := range hits {
+		svc, ok := serviceMap[h.ID]
+		if !ok {
+			continue
+		}
+		items = append(items, &response.SearchServiceResDto{
+			Service:    MapServiceToServiceResDto(*svc),
+			GroupID:    svc.GroupID,
+			GroupTitle: groupTitles[svc.GroupID],
+			Score:      h.Score,
+			Highlights: h.Highlights,
+		})
+	}
+
+	return &response.SearchServicesResponse{
+		Query: query,
+		Items: items,
+	}
+}
+
+// MapServicesTreeResponse build cây group theo parent_id, pruneEmpty = true thì bỏ các nhánh không có service
+func MapServicesTreeResponse(groups []*model.ServiceGroup, services []*model.Service, pruneEmpty bool, locales ...string) []*response.ServicesTreeResponse {
+	serviceMap := make(map[string][]response.ServiceResDto)
+	for _, svc := range services {
+		serviceMap[svc.GroupID] = append(serviceMap[svc.GroupID], *MapServiceToServiceResDto(*svc, locales...))
+	}
+
+	nodes := make(map[string]*response.ServicesTreeResponse, len(groups))
+	for _, g := range groups {
+		nodes[g.ID.Hex()] = &response.ServicesTreeResponse{
+			Group:    mapGroupResponse(g, locales),
+			Services: serviceMap[g.ID.Hex()],
+			Children: []*response.ServicesTreeResponse{},
+		}
+	}
+
+	// groups đã sắp theo order nên children giữ đúng thứ tự
+	var roots []*response.ServicesTreeResponse
+	for _, g := range groups {
+		node := nodes[g.ID.Hex()]
+		if parent, ok := nodes[g.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			// Không có cha hoặc cha đã bị xoá thì coi là gốc
+			roots = append(roots, node)
+		}
+	}
+
+	if pruneEmpty {
+		roots = pruneTree(roots)
+	}
+	return roots
+}
+
+// FindTreeNode tìm node theo group id trong cây
+func FindTreeNode(nodes []*response.ServicesTreeResponse, id string) *response.ServicesTreeResponse {
+	for _, n := range nodes {
+		if n.Group.ID == id {
+			return n
+		}
+		if found := FindTreeNode(n.Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func pruneTree(nodes []*response.ServicesTreeResponse) []*response.ServicesTreeResponse {
+	result := make([]*response.ServicesTreeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		n.Children = pruneTree(n.Children)
+		if len(n.Services) > 0 || len(n.Children) > 0 {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func mapGroupResponse(g *model.ServiceGroup, locales []string) response.ServiceGroupResponse {
+	return response.ServiceGroupResponse{
+		ID:       g.ID.Hex(),
+		ParentID: g.ParentID,
+		Title:    localize(g.Title, g.Translations, locales, translationTitle),
+		Order:    g.Order,
+		Icon:     MapIconToIconResDto(g.Icon),
+	}
+}
+
+func MapServiceGroupResponse(g *model.ServiceGroup, locales ...string) response.ServiceGroupResponse {
+	return mapGroupResponse(g, locales)
+}
+
+func MapOwnershipResDto(o *model.Ownership) *response.OwnershipResDto {
+	if o == nil {
+		return nil
+	}
+	return &response.OwnershipResDto{
+		OwnerTeam:    o.OwnerTeam,
+		OwnerStaffID: o.OwnerStaffID,
+		ContactEmail: o.ContactEmail,
+		ContactPhone: o.ContactPhone,
+		SupportUrl:   o.SupportUrl,
+		SLATier:      o.SLATier,
+		DocsUrl:      o.DocsUrl,
+	}
+}
+
+func MapOwnershipRequest(req *request.OwnershipRequest) *model.Ownership {
+	if req == nil {
+		return nil
+	}
+	return &model.Ownership{
+		OwnerTeam:    strings.TrimSpace(req.OwnerTeam),
+		OwnerStaffID: strings.TrimSpace(req.OwnerStaffID),
+		ContactEmail: strings.ToLower(strings.TrimSpace(req.ContactEmail)),
+		ContactPhone: strings.TrimSpace(req.ContactPhone),
+		SupportUrl:   req.SupportUrl,
+		SLATier:      req.SLATier,
+		DocsUrl:      req.DocsUrl,
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AnnouncementTargetService = "service"
+	AnnouncementTargetGroup   = "group"
+
+	AnnouncementKindMaintenance  = "maintenance"
+	AnnouncementKindAnnouncement = "announcement"
+)
+
+// Announcement lịch bảo trì hoặc thông báo gắn với service hoặc group.
+// Gắn với group thì áp dụng cho mọi service trong group và các group con
+type Announcement struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	TargetType string             `bson:"target_type"`
+	TargetID   string             `bson:"target_id"`
+	// OrganizationID của service/group đích, dùng để lọc event theo tổ chức
+	OrganizationID string     `bson:"organization_id,omitempty"`
+	Kind           string     `bson:"kind"`
+	Severity       string     `bson:"severity"` // info, warning, critical
+	Message        string     `bson:"message"`
+	StartsAt       time.Time  `bson:"starts_at"`
+	EndsAt         *time.Time `bson:"ends_at,omitempty"` // nil là đến khi admin xoá
+	DisableLaunch  bool       `bson:"disable_launch"`
+	CreatedBy      string     `bson:"created_by"`
+	CreatedAt      time.Time  `bson:"created_at"`
+	UpdatedAt      time.Time  `bson:"updated_at"`
+}
+
+// ActiveAt đang trong khoảng thời gian hiệu lực
+func (a *Announcement) ActiveAt(t time.Time) bool {
+	return !t.Before(a.StartsAt) && (a.EndsAt == nil || t.Before(*a.EndsAt))
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Asset struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	OrganizationID string             `bson:"organization_id"`
+	FileName       string             `bson:"file_name"`
+	ContentType    string             `bson:"content_type"`
+	Size           int64              `bson:"size"`
+	Width          int                `bson:"width"`
+	Height         int                `bson:"height"`
+	StorageKey     string             `bson:"storage_key"`
+	Variants       []AssetVariant     `bson:"variants"`
+	CreatedBy      string             `bson:"created_by"`
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+// AssetVariant bản resize theo kích thước chuẩn của launcher
+type AssetVariant struct {
+	Size        int    `bson:"size"`
+	Width       int    `bson:"width"`
+	Height      int    `bson:"height"`
+	ContentType string `bson:"content_type"`
+	ByteSize    int64  `bson:"byte_size"`
+	StorageKey  string `bson:"storage_key"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// CatalogChange thay đổi gần nhất của một service/group, mỗi entity chỉ có một bản ghi.
+// Seq tăng dần theo thứ tự ghi, entity bị xoá giữ lại dạng tombstone (Deleted)
+type CatalogChange struct {
+	ID             string `bson:"_id"` // "<entity_type>-<entity_id>"
+	EntityType     string `bson:"entity_type"`
+	EntityID       string `bson:"entity_id"`
+	OrganizationID string `bson:"organization_id"`
+	// OrganizationIDs mọi organization entity từng thuộc về, để organization cũ vẫn nhận tombstone khi entity chuyển đi
+	OrganizationIDs []string  `bson:"organization_ids"`
+	Deleted         bool      `bson:"deleted"`
+	Seq             int64     `bson:"seq"`
+	ChangedAt       time.Time `bson:"changed_at"`
+}
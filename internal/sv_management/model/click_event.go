@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClickEvent một lần user mở service qua /go/:serviceId
+type ClickEvent struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	ServiceID      string             `bson:"service_id"`
+	UserID         string             `bson:"user_id"`
+	OrganizationID string             `bson:"organization_id"`
+	Roles          []string           `bson:"roles"`
+	UserAgent      string             `bson:"user_agent"`
+	ClickedAt      time.Time          `bson:"clicked_at"`
+}
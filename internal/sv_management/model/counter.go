@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Counter bộ đếm tăng dần theo tên. Pending các lượt cấp số mà writer chưa ghi xong
+type Counter struct {
+	ID      string           `bson:"_id"`
+	Value   int64            `bson:"value"`
+	Pending []CounterPending `bson:"pending,omitempty"`
+}
+
+// CounterPending lượt cấp số bắt đầu từ Seq, At là lúc cấp (giờ của mongo)
+type CounterPending struct {
+	Seq int64     `bson:"seq"`
+	At  time.Time `bson:"at"`
+}
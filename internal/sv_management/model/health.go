@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	HealthStatusUp      = "up"
+	HealthStatusDown    = "down"
+	HealthStatusUnknown = "unknown"
+)
+
+// HealthCheckConfig cấu hình probe riêng của service, field rỗng dùng mặc định trong config
+type HealthCheckConfig struct {
+	Disabled       bool   `bson:"disabled"`
+	Method         string `bson:"method,omitempty"` // GET hoặc HEAD
+	ProbeUrl       string `bson:"probe_url,omitempty"`
+	TimeoutMs      int    `bson:"timeout_ms,omitempty"`
+	ExpectedStatus int    `bson:"expected_status,omitempty"` // 0 là mọi status 2xx/3xx
+	Keyword        string `bson:"keyword,omitempty"`         // chỉ dùng với GET
+}
+
+// HealthState kết quả probe gần nhất, lưu ngay trên service để catalog đọc không phải join
+type HealthState struct {
+	Status      string    `bson:"status"`
+	LastChecked time.Time `bson:"last_checked"`
+	StatusCode  int       `bson:"status_code"`
+	LatencyMs   int64     `bson:"latency_ms"`
+	Error       string    `bson:"error,omitempty"`
+	ChangedAt   time.Time `bson:"changed_at"` // lần cuối status đổi, dùng làm "failing since"
+}
+
+// HealthCheck lịch sử từng lần probe
+type HealthCheck struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	ServiceID  string             `bson:"service_id"`
+	Url        string             `bson:"url"`
+	Status     string             `bson:"status"`
+	StatusCode int                `bson:"status_code"`
+	LatencyMs  int64              `bson:"latency_ms"`
+	Error      string             `bson:"error,omitempty"`
+	CheckedAt  time.Time          `bson:"checked_at"`
+}
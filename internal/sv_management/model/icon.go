@@ -0,0 +1,8 @@
+package model
+
+// Icon tương thích với Avatar của gateway: ảnh upload (ImageID/ImageKey) hoặc URL ngoài
+type Icon struct {
+	ImageID  uint64 `bson:"image_id,omitempty"`
+	ImageKey string `bson:"image_key,omitempty"`
+	ImageUrl string `bson:"image_url,omitempty"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxMessage event ghi cùng transaction với thay đổi catalog, relay publish theo thứ tự _id
+type OutboxMessage struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	EventID       string             `bson:"event_id"`
+	EventType     string             `bson:"event_type"`
+	AggregateType string             `bson:"aggregate_type"`
+	AggregateID   string             `bson:"aggregate_id"`
+	Payload       string             `bson:"payload"` // JSON của event
+	CreatedAt     time.Time          `bson:"created_at"`
+	PublishedAt   *time.Time         `bson:"published_at,omitempty"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+}
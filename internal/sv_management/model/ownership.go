@@ -0,0 +1,20 @@
+package model
+
+// Các mức SLA hỗ trợ của service
+const (
+	SLATierGold   = "gold"
+	SLATierSilver = "silver"
+	SLATierBronze = "bronze"
+)
+
+// Ownership đội sở hữu và thông tin hỗ trợ khi service gặp sự cố.
+// OwnerStaffID là nhân sự phụ trách, tên/ảnh được lấy từ user service khi trả ra API
+type Ownership struct {
+	OwnerTeam    string `bson:"owner_team"`
+	OwnerStaffID string `bson:"owner_staff_id,omitempty"`
+	ContactEmail string `bson:"contact_email,omitempty"`
+	ContactPhone string `bson:"contact_phone,omitempty"`
+	SupportUrl   string `bson:"support_url,omitempty"`
+	SLATier      string `bson:"sla_tier,omitempty"`
+	DocsUrl      string `bson:"docs_url,omitempty"`
+}
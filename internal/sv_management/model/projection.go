@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// CatalogView read model của catalog dựng lại từ event store, mỗi document là trạng thái cuối của một service/group
+type CatalogView struct {
+	ID             string    `bson:"_id" json:"id"` // tên stream: "service-<id>", "group-<id>"
+	AggregateType  string    `bson:"aggregate_type" json:"aggregate_type"`
+	AggregateID    string    `bson:"aggregate_id" json:"aggregate_id"`
+	OrganizationID string    `bson:"organization_id" json:"organization_id"`
+	ParentID       string    `bson:"parent_id,omitempty" json:"parent_id,omitempty"` // group chứa service hoặc group cha
+	Path           []string  `bson:"path,omitempty" json:"path,omitempty"`
+	Title          string    `bson:"title" json:"title"`
+	Url            string    `bson:"url,omitempty" json:"url,omitempty"`
+	Status         string    `bson:"status,omitempty" json:"status,omitempty"`
+	Order          int       `bson:"order" json:"order"`
+	Tags           []string  `bson:"tags,omitempty" json:"tags,omitempty"`
+	Roles          []string  `bson:"roles,omitempty" json:"roles,omitempty"`
+	Version        uint64    `bson:"version" json:"version"` // version trong stream của event cuối đã áp dụng
+	LastEventID    string    `bson:"last_event_id" json:"last_event_id"`
+	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ProjectionCheckpoint vị trí trong event store mà projection đã áp dụng tới
+type ProjectionCheckpoint struct {
+	Name            string    `bson:"_id" json:"name"`
+	Started         bool      `bson:"started" json:"started"` // false là chưa áp dụng event nào
+	Commit          uint64    `bson:"commit" json:"commit"`
+	Prepare         uint64    `bson:"prepare" json:"prepare"`
+	Processed       int64     `bson:"processed" json:"processed"` // số event đã đọc từ lần replay gần nhất
+	ReplayRequested bool      `bson:"replay_requested" json:"replay_requested"`
+	UpdatedAt       time.Time `bson:"updated_at" json:"updated_at"`
+}
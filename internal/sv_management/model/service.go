@@ -7,11 +7,34 @@ import (
 )
 
 type Service struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	GroupID   string             `bson:"group_id"`
-	Title     string             `bson:"title"`
-	Url       string             `bson:"url"`
-	Order     int                `bson:"order"`
-	CreatedAt time.Time          `bson:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at"`
+	ID             primitive.ObjectID     `bson:"_id,omitempty"`
+	OrganizationID string                 `bson:"organization_id"`
+	GroupID        string                 `bson:"group_id"`
+	Title          string                 `bson:"title"`
+	Url            string                 `bson:"url"`            // url mặc định
+	Urls           map[string]string      `bson:"urls,omitempty"` // môi trường -> url
+	Order          int                    `bson:"order"`
+	Description    string                 `bson:"description"`
+	Icon           *Icon                  `bson:"icon,omitempty"`
+	Color          string                 `bson:"color"`
+	LaunchMode     string                 `bson:"launch_mode"`
+	Tags           []string               `bson:"tags"`
+	Status         string                 `bson:"status"`
+	Roles          []string               `bson:"roles"`
+	Translations   map[string]Translation `bson:"translations,omitempty"`
+	SSO            *SSOConfig             `bson:"sso,omitempty"`
+	HealthCheck    *HealthCheckConfig     `bson:"health_check,omitempty"`
+	Health         *HealthState           `bson:"health,omitempty"`
+	Ownership      *Ownership             `bson:"ownership,omitempty"`
+	DependsOn      []string               `bson:"depends_on,omitempty"` // id các service mà service này cần để hoạt động
+	CreatedAt      time.Time              `bson:"created_at"`
+	UpdatedAt      time.Time              `bson:"updated_at"`
+}
+
+// UrlFor url của môi trường env, không có thì dùng url mặc định
+func (s *Service) UrlFor(env string) string {
+	if url, ok := s.Urls[env]; ok && url != "" {
+		return url
+	}
+	return s.Url
 }
@@ -7,9 +7,14 @@ import (
 )
 
 type ServiceGroup struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Title     string             `bson:"title"`
-	Order     int                `bson:"order"`
-	CreatedAt time.Time          `bson:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at"`
+	ID             primitive.ObjectID     `bson:"_id,omitempty"`
+	OrganizationID string                 `bson:"organization_id"`
+	ParentID       string                 `bson:"parent_id"`
+	Path           []string               `bson:"path"` // id các group tổ tiên, từ gốc tới cha trực tiếp
+	Title          string                 `bson:"title"`
+	Order          int                    `bson:"order"`
+	Icon           *Icon                  `bson:"icon,omitempty"`
+	Translations   map[string]Translation `bson:"translations,omitempty"`
+	CreatedAt      time.Time              `bson:"created_at"`
+	UpdatedAt      time.Time              `bson:"updated_at"`
 }
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SmartCollection nhóm service tính động theo Query, hiển thị trong catalog như một group
+type SmartCollection struct {
+	ID             primitive.ObjectID     `bson:"_id,omitempty"`
+	OrganizationID string                 `bson:"organization_id"` // rỗng là collection dùng chung
+	Title          string                 `bson:"title"`
+	Order          int                    `bson:"order"`
+	Limit          int                    `bson:"limit"` // 0 là không giới hạn số service
+	Query          CollectionQuery        `bson:"query"`
+	Translations   map[string]Translation `bson:"translations,omitempty"`
+	CreatedBy      string                 `bson:"created_by"`
+	CreatedAt      time.Time              `bson:"created_at"`
+	UpdatedAt      time.Time              `bson:"updated_at"`
+}
+
+// CollectionQuery các điều kiện được AND với nhau, điều kiện rỗng thì bỏ qua
+type CollectionQuery struct {
+	Tags            []string `bson:"tags"`
+	MatchAllTags    bool     `bson:"match_all_tags"` // false: có ít nhất một tag
+	Roles           []string `bson:"roles"`          // service phải giới hạn cho ít nhất một role này
+	OrganizationIDs []string `bson:"organization_ids"`
+}
+
+func (q CollectionQuery) IsEmpty() bool {
+	return len(q.Tags) == 0 && len(q.Roles) == 0 && len(q.OrganizationIDs) == 0
+}
+
+// Matches query rỗng không khớp service nào
+func (q CollectionQuery) Matches(svc *Service) bool {
+	if q.IsEmpty() {
+		return false
+	}
+	if len(q.Tags) > 0 {
+		has := func(tag string) bool { return slices.Contains(svc.Tags, tag) }
+		if q.MatchAllTags && !all(q.Tags, has) || !q.MatchAllTags && !slices.ContainsFunc(q.Tags, has) {
+			return false
+		}
+	}
+	if len(q.Roles) > 0 && !slices.ContainsFunc(q.Roles, func(role string) bool { return slices.Contains(svc.Roles, role) }) {
+		return false
+	}
+	if len(q.OrganizationIDs) > 0 && !slices.Contains(q.OrganizationIDs, svc.OrganizationID) {
+		return false
+	}
+	return true
+}
+
+func all(items []string, fn func(string) bool) bool {
+	for _, item := range items {
+		if !fn(item) {
+			return false
+		}
+	}
+	return true
+}
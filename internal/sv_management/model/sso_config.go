@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// SSOConfig cấu hình launch token cho service của đối tác.
+// Secret/PrivateKey không bao giờ trả ra API, đối tác verify bằng secret đã nhận lúc cấu hình hoặc public key
+type SSOConfig struct {
+	Enabled      bool              `bson:"enabled"`
+	Algorithm    string            `bson:"algorithm"` // HS256 hoặc RS256
+	Secret       string            `bson:"secret,omitempty"`
+	PrivateKey   string            `bson:"private_key,omitempty"` // PEM PKCS#8
+	Audience     string            `bson:"audience"`
+	ClaimMapping map[string]string `bson:"claim_mapping,omitempty"` // claim của mình -> tên claim đối tác cần
+	TTLSeconds   int               `bson:"ttl_seconds"`
+	Param        string            `bson:"param"` // tên query param gắn token vào url
+	UpdatedAt    time.Time         `bson:"updated_at"`
+}
+
+// SSONonce jti của launch token đã phát, xoá khi được dùng hoặc hết hạn
+type SSONonce struct {
+	Nonce     string    `bson:"_id"`
+	ServiceID string    `bson:"service_id"`
+	UserID    string    `bson:"user_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
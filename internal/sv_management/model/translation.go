@@ -0,0 +1,7 @@
+package model
+
+// Translation bản dịch theo locale, Title/Description gốc là của locale mặc định
+type Translation struct {
+	Title       string `bson:"title"`
+	Description string `bson:"description,omitempty"`
+}
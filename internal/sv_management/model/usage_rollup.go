@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// RollupAllRoles role của bản ghi tổng hợp mọi role
+const RollupAllRoles = ""
+
+type UsageRollupKey struct {
+	Day            string `bson:"day"` // yyyy-mm-dd theo UTC
+	ServiceID      string `bson:"service_id"`
+	OrganizationID string `bson:"organization_id"`
+	Role           string `bson:"role"`
+}
+
+// UsageRollup số click theo ngày/service/org/role, do job rollup tính lại từ click_events.
+// Mỗi click được đếm vào bản ghi role "" và vào bản ghi của từng role user đang có
+type UsageRollup struct {
+	ID             UsageRollupKey `bson:"_id"`
+	Day            string         `bson:"day"`
+	ServiceID      string         `bson:"service_id"`
+	OrganizationID string         `bson:"organization_id"`
+	Role           string         `bson:"role"`
+	Clicks         int64          `bson:"clicks"`
+	UserIDs        []string       `bson:"user_ids"`
+	UniqueUsers    int64          `bson:"unique_users"`
+	UpdatedAt      time.Time      `bson:"updated_at"`
+}
+
+// ServiceUsage số liệu của một service trong một khoảng thời gian
+type ServiceUsage struct {
+	ServiceID   string `bson:"_id"`
+	Clicks      int64  `bson:"clicks"`
+	UniqueUsers int64  `bson:"unique_users"`
+}
+
+// DailyUsage số liệu theo ngày của một service
+type DailyUsage struct {
+	Day         string `bson:"_id"`
+	Clicks      int64  `bson:"clicks"`
+	ActiveUsers int64  `bson:"active_users"`
+}
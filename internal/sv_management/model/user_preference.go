@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserPreference tuỳ chỉnh catalog của từng user
+type UserPreference struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	UserID        string             `bson:"user_id"`
+	Favorites     []string           `bson:"favorites"`      // service id theo thứ tự user sắp xếp
+	GroupOrders   map[string]int     `bson:"group_orders"`   // group id -> order riêng của user
+	ServiceOrders map[string]int     `bson:"service_orders"` // service id -> order riêng của user
+	CreatedAt     time.Time          `bson:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+}
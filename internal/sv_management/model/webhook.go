@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusDead      = "dead" // hết số lần retry, nằm trong dead-letter
+)
+
+// WebhookSubscription Secret dùng ký HMAC payload, không trả ra API sau khi tạo
+type WebhookSubscription struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Url         string             `bson:"url"`
+	Secret      string             `bson:"secret"`
+	Events      []string           `bson:"events"` // rỗng là mọi event
+	Active      bool               `bson:"active"`
+	Description string             `bson:"description,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+// WebhookDelivery một event gửi tới một subscription, Payload giữ nguyên byte để retry ký lại giống hệt
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	SubscriptionID string             `bson:"subscription_id"`
+	EventID        string             `bson:"event_id"`
+	EventType      string             `bson:"event_type"`
+	Payload        string             `bson:"payload"`
+	Status         string             `bson:"status"`
+	Attempts       []WebhookAttempt   `bson:"attempts"`
+	NextAttemptAt  *time.Time         `bson:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+type WebhookAttempt struct {
+	AttemptedAt time.Time `bson:"attempted_at"`
+	StatusCode  int       `bson:"status_code,omitempty"`
+	DurationMs  int64     `bson:"duration_ms"`
+	Error       string    `bson:"error,omitempty"`
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Message một event đã encode, Key là aggregate id để broker giữ thứ tự theo aggregate
+type Message struct {
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Broker nơi relay publish event, Publish chỉ trả về nil khi mọi message đã được ghi nhận
+type Broker interface {
+	Publish(ctx context.Context, messages []Message) error
+	Close() error
+}
+
+// MemoryBroker giữ message trong bộ nhớ, dùng trong test
+type MemoryBroker struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, messages []Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, messages...)
+	return nil
+}
+
+// Messages bản sao các message đã publish theo thứ tự
+func (b *MemoryBroker) Messages() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message{}, b.messages...)
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
+
+type multiBroker []Broker
+
+// MultiBroker publish lần lượt lên từng broker (bỏ qua nil), broker nào lỗi thì cả lượt được thử lại
+// nên broker trước có thể nhận trùng. Không còn broker nào thì trả về nil
+func MultiBroker(brokers ...Broker) Broker {
+	var result multiBroker
+	for _, b := range brokers {
+		if b != nil {
+			result = append(result, b)
+		}
+	}
+	switch len(result) {
+	case 0:
+		return nil
+	case 1:
+		return result[0]
+	}
+	return result
+}
+
+func (m multiBroker) Publish(ctx context.Context, messages []Message) error {
+	for _, b := range m {
+		if err := b.Publish(ctx, messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiBroker) Close() error {
+	var errs []error
+	for _, b := range m {
+		errs = append(errs, b.Close())
+	}
+	return errors.Join(errs...)
+}
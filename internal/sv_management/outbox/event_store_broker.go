@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/eventstore"
+)
+
+// EventStoreBroker ghi event vào stream của từng aggregate trong event store
+type EventStoreBroker struct {
+	store    eventstore.Store
+	appended func()
+}
+
+// NewEventStoreBroker appended (có thể nil) được gọi sau mỗi lần ghi, dùng để đánh thức projection
+func NewEventStoreBroker(store eventstore.Store, appended func()) *EventStoreBroker {
+	return &EventStoreBroker{
+		store:    store,
+		appended: appended,
+	}
+}
+
+// Publish gom các message liền nhau cùng stream thành một lần ghi, giữ nguyên thứ tự
+func (b *EventStoreBroker) Publish(ctx context.Context, messages []Message) error {
+	var (
+		stream string
+		batch  []events.Event
+	)
+	for _, m := range messages {
+		event, err := events.Decode(m.Value)
+		if err != nil {
+			return err
+		}
+
+		next := eventstore.StreamName(m.Headers[HeaderAggregateType], m.Key)
+		if next != stream && len(batch) > 0 {
+			if err := b.store.Append(ctx, stream, batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+		stream = next
+		batch = append(batch, event)
+	}
+	if len(batch) > 0 {
+		if err := b.store.Append(ctx, stream, batch); err != nil {
+			return err
+		}
+	}
+
+	if b.appended != nil {
+		b.appended()
+	}
+	return nil
+}
+
+func (b *EventStoreBroker) Close() error {
+	return b.store.Close()
+}
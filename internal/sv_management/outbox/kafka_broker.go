@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaBroker publish lên một topic, partition chọn theo hash của key
+// nên event cùng aggregate luôn vào cùng partition và giữ thứ tự
+type KafkaBroker struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaBroker(brokers []string, topic string) *KafkaBroker {
+	return &KafkaBroker{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  topic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			MaxAttempts:            5,
+			BatchTimeout:           10 * time.Millisecond,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (b *KafkaBroker) Publish(ctx context.Context, messages []Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, m := range messages {
+		headers := make([]kafka.Header, 0, len(m.Headers))
+		for k, v := range m.Headers {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		kafkaMessages = append(kafkaMessages, kafka.Message{
+			Key:     []byte(m.Key),
+			Value:   m.Value,
+			Headers: headers,
+		})
+	}
+	return b.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (b *KafkaBroker) Close() error {
+	return b.writer.Close()
+}
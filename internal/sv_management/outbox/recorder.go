@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"time"
+)
+
+// Recorder ghi thay đổi catalog và event vào outbox trong cùng transaction rồi đánh thức relay
+type Recorder struct {
+	tx    repository.Transactor
+	repo  repository.OutboxRepository
+	relay *Relay
+}
+
+func NewRecorder(tx repository.Transactor, repo repository.OutboxRepository, relay *Relay) *Recorder {
+	return &Recorder{
+		tx:    tx,
+		repo:  repo,
+		relay: relay,
+	}
+}
+
+func (r *Recorder) Record(ctx context.Context, write func(ctx context.Context) ([]events.Event, error)) error {
+	err := r.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		recorded, err := write(ctx)
+		if err != nil {
+			return err
+		}
+
+		messages, err := outboxMessages(recorded)
+		if err != nil {
+			return err
+		}
+		return r.repo.Insert(ctx, messages)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.relay.Notify()
+	return nil
+}
+
+func outboxMessages(recorded []events.Event) ([]*model.OutboxMessage, error) {
+	now := time.Now()
+	messages := make([]*model.OutboxMessage, 0, len(recorded))
+	for _, event := range recorded {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &model.OutboxMessage{
+			EventID:       event.ID,
+			EventType:     event.Type,
+			AggregateType: event.AggregateType(),
+			AggregateID:   event.AggregateID,
+			Payload:       string(payload),
+			CreatedAt:     now,
+		})
+	}
+	return messages, nil
+}
@@ -0,0 +1,175 @@
+package outbox
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	leaseName           = "outbox-relay"
+	defaultPollInterval = 2 * time.Second
+	defaultBatchSize    = 100
+	writeTimeout        = 10 * time.Second
+)
+
+// Header gửi kèm mỗi message
+const (
+	HeaderEventID       = "event_id"
+	HeaderEventType     = "event_type"
+	HeaderAggregateType = "aggregate_type"
+)
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// Relay đọc outbox theo thứ tự ghi và publish lên broker (at-least-once):
+// chỉ đánh dấu published sau khi broker nhận, lỗi thì dừng lượt để không vượt thứ tự.
+// Chỉ instance giữ lease mới chạy relay, event bus của mọi instance nhận event qua Tailer
+type Relay struct {
+	repo        repository.OutboxRepository
+	leaseRepo   repository.LeaseRepository
+	broker      Broker
+	onPublished func()
+	owner       string
+	opts        Options
+	wake        chan struct{}
+	worker      sync.WaitGroup
+}
+
+// NewRelay broker nil thì chỉ đánh dấu published, onPublished (có thể nil) được gọi sau mỗi batch đã publish
+func NewRelay(repo repository.OutboxRepository, leaseRepo repository.LeaseRepository, broker Broker, onPublished func(), opts Options) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	return &Relay{
+		repo:        repo,
+		leaseRepo:   leaseRepo,
+		broker:      broker,
+		onPublished: onPublished,
+		owner:       primitive.NewObjectID().Hex(),
+		opts:        opts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Notify đánh thức relay ngay sau khi transaction commit
+func (r *Relay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start chạy relay tới khi ctx bị huỷ
+func (r *Relay) Start(ctx context.Context) {
+	r.worker.Add(1)
+	go func() {
+		defer r.worker.Done()
+		ticker := time.NewTicker(r.opts.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := r.Run(ctx); err != nil {
+				logger.WriteLogEx("error", "outbox relay failed", map[string]any{
+					"error": err.Error(),
+				})
+			}
+			select {
+			case <-ctx.Done():
+				releaseCtx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+				_ = r.leaseRepo.Release(releaseCtx, leaseName, r.owner)
+				cancel()
+				return
+			case <-ticker.C:
+			case <-r.wake:
+			}
+		}
+	}()
+}
+
+// Wait chờ relay dừng hẳn và trả lease sau khi ctx của Start bị huỷ,
+// message chưa publish vẫn nằm trong outbox cho lần chạy sau
+func (r *Relay) Wait() {
+	r.worker.Wait()
+}
+
+// Run publish mọi message đang chờ nếu instance giữ được lease
+func (r *Relay) Run(ctx context.Context) error {
+	// lease dài hơn vài chu kỳ poll để instance chết thì instance khác nhận thay
+	leader, err := r.leaseRepo.Acquire(ctx, leaseName, r.owner, 5*r.opts.PollInterval+writeTimeout)
+	if err != nil || !leader {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		pending, err := r.repo.Pending(ctx, r.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if err := r.publish(ctx, pending); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (r *Relay) publish(ctx context.Context, pending []*model.OutboxMessage) error {
+	messages := make([]Message, 0, len(pending))
+	ids := make([]primitive.ObjectID, 0, len(pending))
+	for _, m := range pending {
+		messages = append(messages, Message{
+			Key:   m.AggregateID,
+			Value: []byte(m.Payload),
+			Headers: map[string]string{
+				HeaderEventID:       m.EventID,
+				HeaderEventType:     m.EventType,
+				HeaderAggregateType: m.AggregateType,
+			},
+		})
+		ids = append(ids, m.ID)
+	}
+
+	if err := r.publishToBroker(ctx, messages); err != nil {
+		writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+		defer cancel()
+		if recordErr := r.repo.RecordFailure(writeCtx, pending[0].ID, err); recordErr != nil {
+			logger.WriteLogEx("error", "save outbox failure failed", map[string]any{
+				"error": recordErr.Error(),
+			})
+		}
+		return err
+	}
+
+	// broker đã nhận, nếu đánh dấu lỗi thì lượt sau publish lại (consumer bỏ trùng theo event_id)
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+	if err := r.repo.MarkPublished(writeCtx, ids, time.Now()); err != nil {
+		return err
+	}
+
+	if r.onPublished != nil {
+		r.onPublished()
+	}
+	return nil
+}
+
+func (r *Relay) publishToBroker(ctx context.Context, messages []Message) error {
+	if r.broker == nil {
+		return nil
+	}
+	return r.broker.Publish(ctx, messages)
+}
@@ -0,0 +1,212 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"services-management/internal/sv_management/model"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryOutbox giữ outbox trong bộ nhớ theo thứ tự ghi
+type memoryOutbox struct {
+	mu       sync.Mutex
+	messages []*model.OutboxMessage
+	failures []primitive.ObjectID
+}
+
+func (o *memoryOutbox) Insert(_ context.Context, messages []*model.OutboxMessage) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, m := range messages {
+		m.ID = primitive.NewObjectID()
+		o.messages = append(o.messages, m)
+	}
+	return nil
+}
+
+func (o *memoryOutbox) Pending(_ context.Context, limit int) ([]*model.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var pending []*model.OutboxMessage
+	for _, m := range o.messages {
+		if m.PublishedAt == nil && len(pending) < limit {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+func (o *memoryOutbox) MarkPublished(_ context.Context, ids []primitive.ObjectID, at time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, id := range ids {
+		for _, m := range o.messages {
+			if m.ID == id {
+				m.PublishedAt = &at
+			}
+		}
+	}
+	return nil
+}
+
+func (o *memoryOutbox) Published(_ context.Context, at time.Time, afterID primitive.ObjectID, limit int) ([]*model.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var published []*model.OutboxMessage
+	for _, m := range o.messages {
+		if m.PublishedAt == nil {
+			continue
+		}
+		if m.PublishedAt.After(at) || m.PublishedAt.Equal(at) && m.ID.Hex() > afterID.Hex() {
+			published = append(published, m)
+		}
+	}
+	sort.Slice(published, func(i, j int) bool {
+		a, b := published[i], published[j]
+		if !a.PublishedAt.Equal(*b.PublishedAt) {
+			return a.PublishedAt.Before(*b.PublishedAt)
+		}
+		return a.ID.Hex() < b.ID.Hex()
+	})
+	if limit > 0 && len(published) > limit {
+		published = published[:limit]
+	}
+	return published, nil
+}
+
+func (o *memoryOutbox) RecordFailure(_ context.Context, id primitive.ObjectID, cause error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.failures = append(o.failures, id)
+	for _, m := range o.messages {
+		if m.ID == id {
+			m.Attempts++
+			m.LastError = cause.Error()
+		}
+	}
+	return nil
+}
+
+func (o *memoryOutbox) CountPending(ctx context.Context) (int64, error) {
+	pending, err := o.Pending(ctx, len(o.messages))
+	return int64(len(pending)), err
+}
+
+func (o *memoryOutbox) EnsureIndexes(context.Context) error {
+	return nil
+}
+
+// singleLease lease luôn thuộc về người gọi
+type singleLease struct{}
+
+func (singleLease) Acquire(context.Context, string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (singleLease) Release(context.Context, string, string) error {
+	return nil
+}
+
+// flakyBroker lỗi ở lần publish đầu tiên rồi chuyển cho MemoryBroker
+type flakyBroker struct {
+	*MemoryBroker
+	failed bool
+}
+
+var errBrokerDown = errors.New("broker down")
+
+func (b *flakyBroker) Publish(ctx context.Context, messages []Message) error {
+	if !b.failed {
+		b.failed = true
+		return errBrokerDown
+	}
+	return b.MemoryBroker.Publish(ctx, messages)
+}
+
+func insertMessages(t *testing.T, repo *memoryOutbox, aggregateIDs ...string) {
+	t.Helper()
+	messages := make([]*model.OutboxMessage, 0, len(aggregateIDs))
+	for i, id := range aggregateIDs {
+		messages = append(messages, &model.OutboxMessage{
+			EventID:       fmt.Sprintf("evt-%d", i),
+			EventType:     "service.updated",
+			AggregateType: "service",
+			AggregateID:   id,
+			Payload:       "{}",
+		})
+	}
+	if err := repo.Insert(context.Background(), messages); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRelayKeepsOrderPerAggregate(t *testing.T) {
+	repo := &memoryOutbox{}
+	insertMessages(t, repo, "a", "b", "a", "c", "b", "a", "c")
+	broker := NewMemoryBroker()
+
+	// batch nhỏ để relay phải đọc nhiều lượt
+	relay := NewRelay(repo, singleLease{}, broker, nil, Options{BatchSize: 2})
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	published := broker.Messages()
+	if len(published) != 7 {
+		t.Fatalf("published %d messages, want 7", len(published))
+	}
+	want := map[string][]string{
+		"a": {"evt-0", "evt-2", "evt-5"},
+		"b": {"evt-1", "evt-4"},
+		"c": {"evt-3", "evt-6"},
+	}
+	got := map[string][]string{}
+	for _, m := range published {
+		got[m.Key] = append(got[m.Key], m.Headers[HeaderEventID])
+	}
+	for key, ids := range want {
+		if fmt.Sprint(got[key]) != fmt.Sprint(ids) {
+			t.Fatalf("aggregate %s published %v, want %v", key, got[key], ids)
+		}
+	}
+	if pending, _ := repo.CountPending(context.Background()); pending != 0 {
+		t.Fatalf("%d messages still pending", pending)
+	}
+}
+
+func TestRelayRedeliversAfterFailedPublish(t *testing.T) {
+	repo := &memoryOutbox{}
+	insertMessages(t, repo, "a", "a")
+	broker := &flakyBroker{MemoryBroker: NewMemoryBroker()}
+	relay := NewRelay(repo, singleLease{}, broker, nil, Options{})
+
+	if err := relay.Run(context.Background()); !errors.Is(err, errBrokerDown) {
+		t.Fatalf("first run error = %v, want %v", err, errBrokerDown)
+	}
+	if pending, _ := repo.CountPending(context.Background()); pending != 2 {
+		t.Fatalf("%d messages pending after failure, want 2", pending)
+	}
+	if len(repo.failures) != 1 || repo.failures[0] != repo.messages[0].ID {
+		t.Fatalf("failures = %v, want the oldest message", repo.failures)
+	}
+	if repo.messages[0].Attempts != 1 || repo.messages[0].LastError != errBrokerDown.Error() {
+		t.Fatalf("oldest message attempts %d error %q", repo.messages[0].Attempts, repo.messages[0].LastError)
+	}
+
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	published := broker.Messages()
+	if len(published) != 2 || published[0].Headers[HeaderEventID] != "evt-0" || published[1].Headers[HeaderEventID] != "evt-1" {
+		t.Fatalf("published %+v after retry, want evt-0 then evt-1", published)
+	}
+	if pending, _ := repo.CountPending(context.Background()); pending != 0 {
+		t.Fatalf("%d messages still pending", pending)
+	}
+}
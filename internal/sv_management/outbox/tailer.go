@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// tailLookback mỗi lượt đọc lại các message publish trong khoảng này trước message mới nhất đã thấy,
+// vì batch của relay không được đánh dấu published cùng lúc và đồng hồ giữa các instance lệch nhau.
+// Phải lớn hơn writeTimeout của relay
+const tailLookback = time.Minute
+
+// Tailer chạy trên mọi instance: đọc message relay đã publish theo thứ tự published_at
+// rồi chuyển cho event bus trong process (SSE, webhook), bỏ trùng theo event id.
+// Không cần lease nên replica nào cũng nhận đủ event catalog
+type Tailer struct {
+	repo   repository.OutboxRepository
+	local  events.Publisher
+	opts   Options
+	wake   chan struct{}
+	worker sync.WaitGroup
+
+	// latest published_at lớn nhất đã đọc, seen các event đã chuyển trong khoảng tailLookback
+	latest time.Time
+	seen   map[string]time.Time
+}
+
+// NewTailer chỉ chuyển các message được publish từ lúc khởi động (lùi lại tailLookback)
+func NewTailer(repo repository.OutboxRepository, local events.Publisher, opts Options) *Tailer {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	return &Tailer{
+		repo:   repo,
+		local:  local,
+		opts:   opts,
+		wake:   make(chan struct{}, 1),
+		latest: time.Now(),
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// Notify đánh thức tailer ngay sau khi relay của instance này publish xong một batch
+func (t *Tailer) Notify() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start chạy tailer tới khi ctx bị huỷ
+func (t *Tailer) Start(ctx context.Context) {
+	t.worker.Add(1)
+	go func() {
+		defer t.worker.Done()
+		ticker := time.NewTicker(t.opts.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := t.Run(ctx); err != nil {
+				logger.WriteLogEx("error", "outbox tailer failed", map[string]any{
+					"error": err.Error(),
+				})
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-t.wake:
+			}
+		}
+	}()
+}
+
+// Wait chờ tailer dừng hẳn sau khi ctx của Start bị huỷ
+func (t *Tailer) Wait() {
+	t.worker.Wait()
+}
+
+// Run chuyển các message mới được publish, không chạy đồng thời với chính nó
+func (t *Tailer) Run(ctx context.Context) error {
+	at := t.latest.Add(-tailLookback)
+	afterID := primitive.NilObjectID
+
+	for ctx.Err() == nil {
+		published, err := t.repo.Published(ctx, at, afterID, t.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range published {
+			at, afterID = *m.PublishedAt, m.ID
+			if at.After(t.latest) {
+				t.latest = at
+			}
+			if _, ok := t.seen[m.EventID]; ok {
+				continue
+			}
+			t.seen[m.EventID] = at
+
+			event, err := events.Decode([]byte(m.Payload))
+			if err != nil {
+				logger.WriteLogEx("warn", "decode outbox event failed", map[string]any{
+					"event_id": m.EventID,
+					"error":    err.Error(),
+				})
+				continue
+			}
+			t.local.Publish(ctx, event)
+		}
+
+		if len(published) < t.opts.BatchSize {
+			break
+		}
+	}
+
+	// event cũ hơn khoảng đọc lại không bao giờ được trả về nữa
+	horizon := t.latest.Add(-tailLookback)
+	for id, publishedAt := range t.seen {
+		if publishedAt.Before(horizon) {
+			delete(t.seen, id)
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingBus ghi lại id các event được chuyển tới
+type recordingBus struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (b *recordingBus) Publish(_ context.Context, event events.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ids = append(b.ids, event.ID)
+}
+
+func (b *recordingBus) received() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return fmt.Sprint(b.ids)
+}
+
+// insertPublished ghi message đã được relay publish lúc at
+func insertPublished(t *testing.T, repo *memoryOutbox, eventID string, at time.Time) {
+	t.Helper()
+	payload, err := json.Marshal(events.Event{
+		ID:          eventID,
+		Type:        events.ServiceUpdated,
+		AggregateID: "svc",
+		OccurredAt:  at,
+		Data:        events.ServiceData{ID: "svc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Insert(context.Background(), []*model.OutboxMessage{{
+		EventID:     eventID,
+		EventType:   events.ServiceUpdated,
+		AggregateID: "svc",
+		Payload:     string(payload),
+		PublishedAt: &at,
+	}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runTailer(t *testing.T, tailer *Tailer) {
+	t.Helper()
+	if err := tailer.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTailerFeedsEveryInstance(t *testing.T) {
+	repo := &memoryOutbox{}
+	// hai instance, không instance nào giữ lease của relay
+	first, second := &recordingBus{}, &recordingBus{}
+	firstTailer := NewTailer(repo, first, Options{})
+	secondTailer := NewTailer(repo, second, Options{})
+
+	now := time.Now()
+	insertPublished(t, repo, "e1", now)
+	insertPublished(t, repo, "e2", now.Add(time.Millisecond))
+	runTailer(t, firstTailer)
+	runTailer(t, secondTailer)
+
+	insertPublished(t, repo, "e3", now.Add(2*time.Millisecond))
+	runTailer(t, firstTailer)
+	runTailer(t, secondTailer)
+
+	for name, bus := range map[string]*recordingBus{"first": first, "second": second} {
+		if got := bus.received(); got != "[e1 e2 e3]" {
+			t.Fatalf("%s instance received %s, want [e1 e2 e3] once each", name, got)
+		}
+	}
+}
+
+func TestTailerPicksUpLateMarkedMessages(t *testing.T) {
+	repo := &memoryOutbox{}
+	bus := &recordingBus{}
+	tailer := NewTailer(repo, bus, Options{})
+
+	now := time.Now()
+	insertPublished(t, repo, "e2", now.Add(time.Second))
+	runTailer(t, tailer)
+
+	// e1 được đánh dấu published trước e2 nhưng chỉ đọc được sau khi tailer đã thấy e2
+	insertPublished(t, repo, "e1", now)
+	runTailer(t, tailer)
+	runTailer(t, tailer)
+
+	if got := bus.received(); got != "[e2 e1]" {
+		t.Fatalf("received %s, want [e2 e1]", got)
+	}
+}
+
+func TestTailerPagesThroughOneBatch(t *testing.T) {
+	repo := &memoryOutbox{}
+	bus := &recordingBus{}
+	// cả batch của relay có cùng published_at, lớn hơn BatchSize của tailer
+	tailer := NewTailer(repo, bus, Options{BatchSize: 2})
+
+	at := time.Now()
+	for i := range 5 {
+		insertPublished(t, repo, fmt.Sprintf("e%d", i), at)
+	}
+	runTailer(t, tailer)
+	runTailer(t, tailer)
+
+	if got := bus.received(); got != "[e0 e1 e2 e3 e4]" {
+		t.Fatalf("received %s, want [e0 e1 e2 e3 e4]", got)
+	}
+}
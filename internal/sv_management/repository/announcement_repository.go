@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnnouncementFilter field rỗng thì bỏ qua
+type AnnouncementFilter struct {
+	TargetType string
+	TargetID   string
+	Kind       string
+	// EndsAfter bỏ các announcement đã kết thúc trước thời điểm này
+	EndsAfter *time.Time
+	// StartsBefore bỏ các announcement bắt đầu sau thời điểm này
+	StartsBefore *time.Time
+}
+
+func (f AnnouncementFilter) toBson() bson.M {
+	query := bson.M{}
+	if f.TargetType != "" {
+		query["target_type"] = f.TargetType
+	}
+	if f.TargetID != "" {
+		query["target_id"] = f.TargetID
+	}
+	if f.Kind != "" {
+		query["kind"] = f.Kind
+	}
+	if f.StartsBefore != nil {
+		query["starts_at"] = bson.M{"$lte": *f.StartsBefore}
+	}
+	if f.EndsAfter != nil {
+		query["$or"] = bson.A{
+			bson.M{"ends_at": bson.M{"$gt": *f.EndsAfter}},
+			bson.M{"ends_at": nil},
+		}
+	}
+	return query
+}
+
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement *model.Announcement) error
+	Update(ctx context.Context, announcement *model.Announcement) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Announcement, error)
+	Find(ctx context.Context, filter AnnouncementFilter) ([]*model.Announcement, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type announcementRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAnnouncementRepository(collection *mongo.Collection) AnnouncementRepository {
+	return &announcementRepository{
+		collection: collection,
+	}
+}
+
+func (r *announcementRepository) Create(ctx context.Context, announcement *model.Announcement) error {
+	now := time.Now()
+	announcement.CreatedAt = now
+	announcement.UpdatedAt = now
+	_, err := r.collection.InsertOne(ctx, announcement)
+	return err
+}
+
+func (r *announcementRepository) Update(ctx context.Context, announcement *model.Announcement) error {
+	announcement.UpdatedAt = time.Now()
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": announcement.ID}, announcement)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *announcementRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *announcementRepository) GetByID(ctx context.Context, id string) (*model.Announcement, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	var announcement model.Announcement
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&announcement); err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// Find sắp theo thời gian bắt đầu
+func (r *announcementRepository) Find(ctx context.Context, filter AnnouncementFilter) ([]*model.Announcement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "starts_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter.toBson(), opts)
+	if err != nil {
+		return nil, err
+	}
+	var announcements []*model.Announcement
+	if err := cursor.All(ctx, &announcements); err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+func (r *announcementRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}}},
+		{Keys: bson.D{{Key: "ends_at", Value: 1}, {Key: "starts_at", Value: 1}}},
+	})
+	return err
+}
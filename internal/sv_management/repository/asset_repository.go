@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AssetRepository interface {
+	Create(ctx context.Context, asset *model.Asset) error
+	GetByID(ctx context.Context, id string) (*model.Asset, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type assetRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAssetRepository(collection *mongo.Collection) AssetRepository {
+	return &assetRepository{
+		collection: collection,
+	}
+}
+
+func (r *assetRepository) Create(ctx context.Context, asset *model.Asset) error {
+	// Nếu chưa có _id thì tự sinh
+	if asset.ID.IsZero() {
+		asset.ID = primitive.NewObjectID()
+	}
+	asset.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, asset)
+	return err
+}
+
+func (r *assetRepository) GetByID(ctx context.Context, id string) (*model.Asset, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var asset model.Asset
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&asset); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (r *assetRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
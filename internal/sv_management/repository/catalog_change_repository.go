@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CatalogChangeFilter struct {
+	// OrganizationID chỉ lấy entity từng thuộc organization và entity dùng chung (không có organization),
+	// rỗng là chỉ lấy entity dùng chung
+	OrganizationID string
+	AfterSeq       int64
+	// UpToSeq chỉ lấy seq nhỏ hơn hoặc bằng, 0 là không giới hạn
+	UpToSeq int64
+}
+
+type CatalogChangeRepository interface {
+	// Upsert ghi đè bản ghi theo entity và cộng dồn organization_ids,
+	// dùng ctx của transaction để ghi cùng thay đổi catalog
+	Upsert(ctx context.Context, changes []*model.CatalogChange) error
+	// Since các thay đổi có seq trong (AfterSeq, UpToSeq], seq tăng dần
+	Since(ctx context.Context, filter CatalogChangeFilter, limit int) ([]*model.CatalogChange, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type catalogChangeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCatalogChangeRepository(collection *mongo.Collection) CatalogChangeRepository {
+	return &catalogChangeRepository{
+		collection: collection,
+	}
+}
+
+func (r *catalogChangeRepository) Upsert(ctx context.Context, changes []*model.CatalogChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(changes))
+	for _, change := range changes {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": change.ID}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"entity_type":     change.EntityType,
+					"entity_id":       change.EntityID,
+					"organization_id": change.OrganizationID,
+					"deleted":         change.Deleted,
+					"seq":             change.Seq,
+					"changed_at":      change.ChangedAt,
+				},
+				"$addToSet": bson.M{"organization_ids": change.OrganizationID},
+			}).
+			SetUpsert(true))
+	}
+	_, err := r.collection.BulkWrite(ctx, models)
+	return err
+}
+
+func (r *catalogChangeRepository) Since(ctx context.Context, filter CatalogChangeFilter, limit int) ([]*model.CatalogChange, error) {
+	seq := bson.M{"$gt": filter.AfterSeq}
+	if filter.UpToSeq > 0 {
+		seq["$lte"] = filter.UpToSeq
+	}
+	query := bson.M{
+		"seq":              seq,
+		"organization_ids": bson.M{"$in": bson.A{filter.OrganizationID, ""}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	changes := make([]*model.CatalogChange, 0)
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *catalogChangeRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "seq", Value: 1}}},
+		{Keys: bson.D{{Key: "organization_ids", Value: 1}, {Key: "seq", Value: 1}}},
+	})
+	return err
+}
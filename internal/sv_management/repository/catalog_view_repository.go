@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CatalogViewRepository read model do projection ghi, chỉ projection được ghi vào collection này
+type CatalogViewRepository interface {
+	Upsert(ctx context.Context, view *model.CatalogView) error
+	Delete(ctx context.Context, id string) error
+	// UpdateOrders đặt order theo thứ tự ids (id là tên stream), bắt đầu từ 1
+	UpdateOrders(ctx context.Context, ids []string, eventID string) error
+	// Clear xoá toàn bộ read model trước khi replay
+	Clear(ctx context.Context) error
+	FindByOrganization(ctx context.Context, organizationID, aggregateType string) ([]*model.CatalogView, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type catalogViewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCatalogViewRepository(collection *mongo.Collection) CatalogViewRepository {
+	return &catalogViewRepository{
+		collection: collection,
+	}
+}
+
+func (r *catalogViewRepository) Upsert(ctx context.Context, view *model.CatalogView) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": view.ID}, view, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *catalogViewRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *catalogViewRepository) UpdateOrders(ctx context.Context, ids []string, eventID string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(ids))
+	for i, id := range ids {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"order": i + 1, "last_event_id": eventID, "updated_at": now}}))
+	}
+	_, err := r.collection.BulkWrite(ctx, models)
+	return err
+}
+
+func (r *catalogViewRepository) Clear(ctx context.Context) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{})
+	return err
+}
+
+func (r *catalogViewRepository) FindByOrganization(ctx context.Context, organizationID, aggregateType string) ([]*model.CatalogView, error) {
+	filter := bson.M{"organization_id": organizationID}
+	if aggregateType != "" {
+		filter["aggregate_type"] = aggregateType
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "aggregate_type", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "order", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	views := make([]*model.CatalogView, 0)
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func (r *catalogViewRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "organization_id", Value: 1},
+			{Key: "aggregate_type", Value: 1},
+			{Key: "parent_id", Value: 1},
+			{Key: "order", Value: 1},
+		},
+	})
+	return err
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ClickEventRepository interface {
+	InsertMany(ctx context.Context, events []*model.ClickEvent) error
+	Earliest(ctx context.Context) (*time.Time, error)
+	RollupDaily(ctx context.Context, since time.Time, target string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+type clickEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewClickEventRepository(collection *mongo.Collection) ClickEventRepository {
+	return &clickEventRepository{
+		collection: collection,
+	}
+}
+
+func (r *clickEventRepository) InsertMany(ctx context.Context, events []*model.ClickEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(events))
+	for i, event := range events {
+		docs[i] = event
+	}
+	// unordered: một document lỗi không chặn cả batch
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// Earliest thời điểm click sớm nhất, nil nếu chưa có click nào
+func (r *clickEventRepository) Earliest(ctx context.Context) (*time.Time, error) {
+	var event model.ClickEvent
+	err := r.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "clicked_at", Value: 1}})).Decode(&event)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event.ClickedAt, nil
+}
+
+// RollupDaily tính lại toàn bộ rollup của các ngày từ since (UTC) và ghi đè vào collection target.
+// Ghi đè theo _id nên chạy lại bao nhiêu lần cũng cho cùng kết quả
+func (r *clickEventRepository) RollupDaily(ctx context.Context, since time.Time, target string) error {
+	since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"clicked_at": bson.M{"$gte": since}}}},
+		{{Key: "$project", Value: bson.M{
+			"day":             bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$clicked_at", "timezone": "UTC"}},
+			"service_id":      1,
+			"organization_id": bson.M{"$ifNull": bson.A{"$organization_id", ""}},
+			"user_id":         1,
+			// role "" là tổng của mọi role
+			"role": bson.M{"$setUnion": bson.A{bson.A{model.RollupAllRoles}, bson.M{"$ifNull": bson.A{"$roles", bson.A{}}}}},
+		}}},
+		{{Key: "$unwind", Value: "$role"}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.D{
+				{Key: "day", Value: "$day"},
+				{Key: "service_id", Value: "$service_id"},
+				{Key: "organization_id", Value: "$organization_id"},
+				{Key: "role", Value: "$role"},
+			},
+			"clicks":   bson.M{"$sum": 1},
+			"user_ids": bson.M{"$addToSet": "$user_id"},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"day":             "$_id.day",
+			"service_id":      "$_id.service_id",
+			"organization_id": "$_id.organization_id",
+			"role":            "$_id.role",
+			"unique_users":    bson.M{"$size": "$user_ids"},
+			"updated_at":      "$$NOW",
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           target,
+			"on":             "_id",
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+func (r *clickEventRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "service_id", Value: 1}, {Key: "clicked_at", Value: -1}}},
+		{Keys: bson.D{{Key: "clicked_at", Value: -1}}},
+	})
+	return err
+}
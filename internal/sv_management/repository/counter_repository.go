@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// counterPendingExpiry lượt cấp số chưa Done quá thời gian này coi như writer đã chết giữa chừng
+const counterPendingExpiry = time.Minute
+
+// CounterRepository bộ đếm tăng dần theo tên.
+// Trong transaction, các transaction cùng tăng một bộ đếm bị xung đột ghi nên số được cấp theo đúng thứ tự commit.
+// Không có transaction thì số được cấp trước có thể ghi xong sau, người đọc dùng Pending để không bỏ qua nó
+type CounterRepository interface {
+	// Next cấp n số liên tiếp, trả về số cuối cùng. Lượt cấp nằm trong Pending tới khi gọi Done
+	Next(ctx context.Context, name string, n int64) (int64, error)
+	// Done bỏ lượt cấp bắt đầu từ first khỏi Pending sau khi writer ghi xong (hoặc bỏ cuộc)
+	Done(ctx context.Context, name string, first int64) error
+	// Current số lớn nhất đã cấp, 0 nếu chưa cấp lần nào
+	Current(ctx context.Context, name string) (int64, error)
+	// Get số lớn nhất đã cấp cùng các lượt cấp chưa Done và chưa hết hạn, đọc trong một lần
+	Get(ctx context.Context, name string) (*model.Counter, error)
+}
+
+type counterRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCounterRepository(collection *mongo.Collection) CounterRepository {
+	return &counterRepository{
+		collection: collection,
+	}
+}
+
+func (r *counterRepository) Next(ctx context.Context, name string, n int64) (int64, error) {
+	// tăng value và ghi lượt cấp trong cùng một update để người đọc không thấy số mới mà thiếu pending,
+	// đồng thời dọn các lượt cấp đã hết hạn
+	expiredBefore := bson.M{"$subtract": bson.A{"$$NOW", counterPendingExpiry.Milliseconds()}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"value": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$value", 0}}, n}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"pending": bson.M{"$concatArrays": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$pending", bson.A{}}},
+					"cond":  bson.M{"$gte": bson.A{"$$this.at", expiredBefore}},
+				}},
+				bson.A{bson.M{"seq": bson.M{"$subtract": bson.A{"$value", n - 1}}, "at": "$$NOW"}},
+			}},
+		}}},
+	}
+
+	var c model.Counter
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": name}, update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&c)
+	return c.Value, err
+}
+
+func (r *counterRepository) Done(ctx context.Context, name string, first int64) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": name}, bson.M{
+		"$pull": bson.M{"pending": bson.M{"seq": first}},
+	})
+	return err
+}
+
+func (r *counterRepository) Current(ctx context.Context, name string) (int64, error) {
+	c, err := r.Get(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	return c.Value, nil
+}
+
+func (r *counterRepository) Get(ctx context.Context, name string) (*model.Counter, error) {
+	var c model.Counter
+	err := r.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &model.Counter{ID: name}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// lệch đồng hồ giữa instance và mongo nhỏ hơn nhiều so với counterPendingExpiry
+	live := c.Pending[:0]
+	for _, p := range c.Pending {
+		if time.Since(p.At) < counterPendingExpiry {
+			live = append(live, p)
+		}
+	}
+	c.Pending = live
+	return &c, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type HealthCheckRepository interface {
+	Insert(ctx context.Context, check *model.HealthCheck) error
+	// History lịch sử mới nhất trước
+	History(ctx context.Context, serviceID string, limit int) ([]*model.HealthCheck, error)
+	// Scan đọc lịch sử từ since theo service_id rồi checked_at tăng dần, không load hết vào bộ nhớ
+	Scan(ctx context.Context, serviceIDs []string, since time.Time, fn func(check *model.HealthCheck) error) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+type healthCheckRepository struct {
+	collection *mongo.Collection
+	retention  time.Duration
+}
+
+// NewHealthCheckRepository lịch sử quá retention bị TTL index xoá
+func NewHealthCheckRepository(collection *mongo.Collection, retention time.Duration) HealthCheckRepository {
+	return &healthCheckRepository{
+		collection: collection,
+		retention:  retention,
+	}
+}
+
+func (r *healthCheckRepository) Insert(ctx context.Context, check *model.HealthCheck) error {
+	_, err := r.collection.InsertOne(ctx, check)
+	return err
+}
+
+func (r *healthCheckRepository) History(ctx context.Context, serviceID string, limit int) ([]*model.HealthCheck, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "checked_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"service_id": serviceID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var checks []*model.HealthCheck
+	if err := cursor.All(ctx, &checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+func (r *healthCheckRepository) Scan(ctx context.Context, serviceIDs []string, since time.Time, fn func(check *model.HealthCheck) error) error {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "service_id", Value: 1}, {Key: "checked_at", Value: 1}}).
+		SetProjection(bson.M{"service_id": 1, "status": 1, "checked_at": 1, "latency_ms": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"service_id": bson.M{"$in": serviceIDs},
+		"checked_at": bson.M{"$gte": since},
+	}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var check model.HealthCheck
+		if err := cursor.Decode(&check); err != nil {
+			return err
+		}
+		if err := fn(&check); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *healthCheckRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "service_id", Value: 1}, {Key: "checked_at", Value: -1}}},
+		{
+			Keys:    bson.D{{Key: "checked_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(r.retention.Seconds())),
+		},
+	})
+	return err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LeaseRepository khoá có thời hạn để chỉ một instance chạy worker cần thứ tự (outbox relay...)
+type LeaseRepository interface {
+	// Acquire lấy hoặc gia hạn lease name cho owner, false nếu instance khác đang giữ
+	Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name, owner string) error
+}
+
+type leaseRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLeaseRepository(collection *mongo.Collection) LeaseRepository {
+	return &leaseRepository{
+		collection: collection,
+	}
+}
+
+func (r *leaseRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}, bson.M{
+		"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)},
+	}, options.Update().SetUpsert(true))
+	// lease đang thuộc instance khác: filter không khớp nên upsert trùng _id
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *leaseRepository) Release(ctx context.Context, name, owner string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+	return err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepository interface {
+	// Insert dùng ctx của transaction để ghi cùng thay đổi catalog
+	Insert(ctx context.Context, messages []*model.OutboxMessage) error
+	// Pending message chưa publish, cũ nhất trước
+	Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error)
+	MarkPublished(ctx context.Context, ids []primitive.ObjectID, at time.Time) error
+	// Published message đã publish sau vị trí (at, afterID), theo thứ tự published_at rồi _id
+	Published(ctx context.Context, at time.Time, afterID primitive.ObjectID, limit int) ([]*model.OutboxMessage, error)
+	RecordFailure(ctx context.Context, id primitive.ObjectID, cause error) error
+	CountPending(ctx context.Context) (int64, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type outboxRepository struct {
+	collection *mongo.Collection
+	retention  time.Duration
+}
+
+// NewOutboxRepository message đã publish quá retention bị TTL index xoá
+func NewOutboxRepository(collection *mongo.Collection, retention time.Duration) OutboxRepository {
+	return &outboxRepository{
+		collection: collection,
+		retention:  retention,
+	}
+}
+
+func (r *outboxRepository) Insert(ctx context.Context, messages []*model.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	docs := make([]any, len(messages))
+	for i, m := range messages {
+		if m.ID.IsZero() {
+			m.ID = primitive.NewObjectID()
+		}
+		docs[i] = m
+	}
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *outboxRepository) Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"published_at": nil}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*model.OutboxMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, ids []primitive.ObjectID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{
+		"$set":   bson.M{"published_at": at},
+		"$unset": bson.M{"last_error": ""},
+	})
+	return err
+}
+
+func (r *outboxRepository) Published(ctx context.Context, at time.Time, afterID primitive.ObjectID, limit int) ([]*model.OutboxMessage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "published_at", Value: 1}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"published_at": bson.M{"$gt": at}},
+		bson.M{"published_at": at, "_id": bson.M{"$gt": afterID}},
+	}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*model.OutboxMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *outboxRepository) RecordFailure(ctx context.Context, id primitive.ObjectID, cause error) error {
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"last_error": cause.Error()},
+	})
+	return err
+}
+
+func (r *outboxRepository) CountPending(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"published_at": nil})
+}
+
+func (r *outboxRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			// TTL chỉ áp dụng cho document đã có published_at
+			Keys:    bson.D{{Key: "published_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(r.retention.Seconds())).SetName("published_at_ttl"),
+		},
+	})
+	return err
+}
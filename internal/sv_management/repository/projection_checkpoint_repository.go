@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ProjectionCheckpointRepository interface {
+	// Get trả về mongo.ErrNoDocuments nếu projection chưa chạy lần nào
+	Get(ctx context.Context, name string) (*model.ProjectionCheckpoint, error)
+	// Save lưu vị trí, không đụng tới yêu cầu replay đang chờ
+	Save(ctx context.Context, checkpoint *model.ProjectionCheckpoint) error
+	RequestReplay(ctx context.Context, name string) error
+	// ResetForReplay đưa checkpoint về đầu nếu đang có yêu cầu replay
+	ResetForReplay(ctx context.Context, name string) error
+}
+
+type projectionCheckpointRepository struct {
+	collection *mongo.Collection
+}
+
+func NewProjectionCheckpointRepository(collection *mongo.Collection) ProjectionCheckpointRepository {
+	return &projectionCheckpointRepository{
+		collection: collection,
+	}
+}
+
+func (r *projectionCheckpointRepository) Get(ctx context.Context, name string) (*model.ProjectionCheckpoint, error) {
+	var checkpoint model.ProjectionCheckpoint
+	if err := r.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (r *projectionCheckpointRepository) Save(ctx context.Context, checkpoint *model.ProjectionCheckpoint) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": checkpoint.Name}, bson.M{
+		"$set": bson.M{
+			"started":    checkpoint.Started,
+			"commit":     checkpoint.Commit,
+			"prepare":    checkpoint.Prepare,
+			"processed":  checkpoint.Processed,
+			"updated_at": time.Now(),
+		},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *projectionCheckpointRepository) RequestReplay(ctx context.Context, name string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": name}, bson.M{
+		"$set": bson.M{"replay_requested": true, "updated_at": time.Now()},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *projectionCheckpointRepository) ResetForReplay(ctx context.Context, name string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": name, "replay_requested": true}, bson.M{
+		"$set": bson.M{
+			"started":          false,
+			"commit":           0,
+			"prepare":          0,
+			"processed":        0,
+			"replay_requested": false,
+			"updated_at":       time.Now(),
+		},
+	})
+	return err
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListOptions gom các tham số phân trang và sắp xếp dùng chung cho các repository
+type ListOptions struct {
+	Page     int
+	Size     int
+	SortBy   string
+	SortDesc bool
+}
+
+// findOptions build options.Find() từ ListOptions, Size = 0 nghĩa là lấy tất cả
+func (o ListOptions) findOptions(defaultSort string) *options.FindOptions {
+	sortBy := o.SortBy
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+	direction := 1
+	if o.SortDesc {
+		direction = -1
+	}
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: sortBy, Value: direction},
+		{Key: "_id", Value: 1},
+	})
+	if o.Size > 0 {
+		page := o.Page
+		if page < 1 {
+			page = 1
+		}
+		opts.SetSkip(int64((page - 1) * o.Size)).SetLimit(int64(o.Size))
+	}
+	return opts
+}
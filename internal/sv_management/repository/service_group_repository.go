@@ -11,9 +11,47 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ServiceGroupFilter điều kiện lọc groups, IDs = nil nghĩa là không lọc theo id
+type ServiceGroupFilter struct {
+	IDs      []string
+	ParentID *string // nil là không lọc, "" là group gốc
+}
+
+func (f ServiceGroupFilter) toBson() bson.M {
+	query := bson.M{}
+	if f.IDs != nil {
+		objectIDs := make([]primitive.ObjectID, 0, len(f.IDs))
+		for _, id := range f.IDs {
+			if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+				objectIDs = append(objectIDs, oid)
+			}
+		}
+		query["_id"] = bson.M{"$in": objectIDs}
+	}
+	if f.ParentID != nil {
+		if *f.ParentID == "" {
+			query["parent_id"] = bson.M{"$in": bson.A{nil, ""}}
+		} else {
+			query["parent_id"] = *f.ParentID
+		}
+	}
+	return query
+}
+
 type ServiceGroupRepository interface {
 	Upload(ctx context.Context, group *model.ServiceGroup) error
 	GetAll(ctx context.Context) ([]*model.ServiceGroup, error)
+	Find(ctx context.Context, filter ServiceGroupFilter, opts ListOptions) ([]*model.ServiceGroup, int64, error)
+	GetByID(ctx context.Context, id string) (*model.ServiceGroup, error)
+	Delete(ctx context.Context, id string) error
+	TextSearch(ctx context.Context, text string, limit int) ([]*model.ServiceGroup, error)
+	SetTranslation(ctx context.Context, id, locale string, translation model.Translation) error
+	DeleteTranslation(ctx context.Context, id, locale string) error
+	Move(ctx context.Context, group *model.ServiceGroup, parentID string, path []string, order int) error
+	UpdateOrders(ctx context.Context, ids []string) error
+	// UsesIcon có group nào đang dùng asset làm icon không
+	UsesIcon(ctx context.Context, assetID string) (bool, error)
+	EnsureIndexes(ctx context.Context) error
 }
 
 type serviceGroupRepository struct {
@@ -40,7 +78,75 @@ func (r *serviceGroupRepository) Upload(ctx context.Context, group *model.Servic
 }
 
 func (r *serviceGroupRepository) GetAll(ctx context.Context) ([]*model.ServiceGroup, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{"order", 1}}))
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "order", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var groups []*model.ServiceGroup
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *serviceGroupRepository) Find(ctx context.Context, filter ServiceGroupFilter, opts ListOptions) ([]*model.ServiceGroup, int64, error) {
+	query := filter.toBson()
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts.findOptions("order"))
+	if err != nil {
+		return nil, 0, err
+	}
+	var groups []*model.ServiceGroup
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+func (r *serviceGroupRepository) GetByID(ctx context.Context, id string) (*model.ServiceGroup, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var group model.ServiceGroup
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *serviceGroupRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// TextSearch tìm group theo title qua text index
+func (r *serviceGroupRepository) TextSearch(ctx context.Context, text string, limit int) ([]*model.ServiceGroup, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"$text": bson.M{"$search": text}}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +156,104 @@ func (r *serviceGroupRepository) GetAll(ctx context.Context) ([]*model.ServiceGr
 	}
 	return groups, nil
 }
+
+func (r *serviceGroupRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "title", Value: "text"}},
+			Options: options.Index().
+				SetName("service_group_text_search").
+				SetDefaultLanguage("none"),
+		},
+		{Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "order", Value: 1}}},
+		{Keys: bson.D{{Key: "path", Value: 1}}},
+	})
+	return err
+}
+
+func (r *serviceGroupRepository) SetTranslation(ctx context.Context, id, locale string, translation model.Translation) error {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"translations." + locale: translation, "updated_at": time.Now()},
+	})
+}
+
+func (r *serviceGroupRepository) UsesIcon(ctx context.Context, assetID string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"icon.image_key": assetID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *serviceGroupRepository) DeleteTranslation(ctx context.Context, id, locale string) error {
+	return r.update(ctx, id, bson.M{
+		"$unset": bson.M{"translations." + locale: ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	})
+}
+
+// update trả về mongo.ErrNoDocuments nếu không có group nào khớp id
+func (r *serviceGroupRepository) update(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Move đổi group cha và cập nhật path của toàn bộ cây con
+func (r *serviceGroupRepository) Move(ctx context.Context, group *model.ServiceGroup, parentID string, path []string, order int) error {
+	now := time.Now()
+	err := r.update(ctx, group.ID.Hex(), bson.M{
+		"$set": bson.M{"parent_id": parentID, "path": path, "order": order, "updated_at": now},
+	})
+	if err != nil {
+		return err
+	}
+
+	// path của con cháu: [tổ tiên cũ..., id, ...] -> [tổ tiên mới..., id, ...]
+	id := group.ID.Hex()
+	_, err = r.collection.UpdateMany(ctx, bson.M{"path": id}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"path": bson.M{"$concatArrays": bson.A{
+				path,
+				bson.M{"$slice": bson.A{
+					"$path",
+					bson.M{"$indexOfArray": bson.A{"$path", id}},
+					bson.M{"$size": "$path"},
+				}},
+			}},
+			"updated_at": now,
+		}}},
+	})
+	return err
+}
+
+// UpdateOrders gán order theo vị trí trong ids, bắt đầu từ 1
+func (r *serviceGroupRepository) UpdateOrders(ctx context.Context, ids []string) error {
+	models := make([]mongo.WriteModel, 0, len(ids))
+	now := time.Now()
+	for i, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": objectID}).
+			SetUpdate(bson.M{"$set": bson.M{"order": i + 1, "updated_at": now}}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models)
+	return err
+}
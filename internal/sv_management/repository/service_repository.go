@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"regexp"
 	"services-management/internal/sv_management/model"
+	"services-management/pkg/constants"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -11,9 +13,106 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ServiceFilter điều kiện lọc services, field rỗng thì bỏ qua
+type ServiceFilter struct {
+	GroupIDs       []string
+	OrganizationID string
+	Tag            string
+	Status         string
+	Roles          []string // nil là không lọc, rỗng là chỉ lấy service không giới hạn role
+	Search         string
+	HealthStatus   string
+	OwnerTeam      string
+	OwnerStaffID   string
+	DependsOn      string // service phụ thuộc trực tiếp vào id này
+}
+
+func (f ServiceFilter) toBson() bson.M {
+	query := bson.M{}
+	var and bson.A
+	if len(f.GroupIDs) > 0 {
+		query["group_id"] = bson.M{"$in": f.GroupIDs}
+	}
+	if f.Tag != "" {
+		query["tags"] = f.Tag
+	}
+	if f.Status == string(constants.ServiceStatusActive) {
+		// service tạo trước khi có status (không có hoặc rỗng) được coi là active
+		query["status"] = bson.M{"$in": bson.A{f.Status, "", nil}}
+	} else if f.Status != "" {
+		query["status"] = f.Status
+	}
+	if f.HealthStatus != "" {
+		query["health.status"] = f.HealthStatus
+	}
+	if f.OwnerTeam != "" {
+		query["ownership.owner_team"] = f.OwnerTeam
+	}
+	if f.OwnerStaffID != "" {
+		query["ownership.owner_staff_id"] = f.OwnerStaffID
+	}
+	if f.DependsOn != "" {
+		query["depends_on"] = f.DependsOn
+	}
+	if f.OrganizationID != "" {
+		// Service không gắn organization là service dùng chung
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"organization_id": f.OrganizationID},
+			bson.M{"organization_id": bson.M{"$in": bson.A{nil, ""}}},
+		}})
+	}
+	if f.Roles != nil {
+		// Service không giới hạn roles thì role nào cũng thấy
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"roles": bson.M{"$in": f.Roles}},
+			bson.M{"roles": bson.M{"$in": bson.A{nil, bson.A{}}}},
+		}})
+	}
+	if f.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(f.Search), Options: "i"}
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"title": pattern},
+			bson.M{"url": pattern},
+			bson.M{"description": pattern},
+		}})
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+	return query
+}
+
+// TagCount số service gắn tag
+type TagCount struct {
+	Tag   string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
 type ServiceRepository interface {
 	Upload(ctx context.Context, service *model.Service) error
 	GetAll(ctx context.Context) ([]*model.Service, error)
+	Find(ctx context.Context, filter ServiceFilter, opts ListOptions) ([]*model.Service, int64, error)
+	DistinctGroupIDs(ctx context.Context, filter ServiceFilter) ([]string, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*model.Service, error)
+	TextSearch(ctx context.Context, text string, filter ServiceFilter, limit int) ([]*model.Service, error)
+	GetByID(ctx context.Context, id string) (*model.Service, error)
+	Delete(ctx context.Context, id string) error
+	SetTranslation(ctx context.Context, id, locale string, translation model.Translation) error
+	DeleteTranslation(ctx context.Context, id, locale string) error
+	SetTags(ctx context.Context, id string, tags []string) error
+	// TagCounts đếm service theo tag, sắp theo số service giảm dần
+	TagCounts(ctx context.Context, filter ServiceFilter) ([]TagCount, error)
+	SetSSO(ctx context.Context, id string, sso *model.SSOConfig) error
+	SetHealthCheck(ctx context.Context, id string, cfg *model.HealthCheckConfig) error
+	SetHealth(ctx context.Context, id string, state model.HealthState) error
+	// SetOwnership ownership nil là xoá thông tin sở hữu
+	SetOwnership(ctx context.Context, id string, ownership *model.Ownership) error
+	SetDependencies(ctx context.Context, id string, dependsOn []string) error
+	// HasOrganization có service nào gắn đúng organization này không
+	HasOrganization(ctx context.Context, organizationID string) (bool, error)
+	// UsesIcon có service nào đang dùng asset làm icon không
+	UsesIcon(ctx context.Context, assetID string) (bool, error)
+	EnsureIndexes(ctx context.Context) error
 }
 
 type serviceRepository struct {
@@ -40,7 +139,7 @@ func (r *serviceRepository) Upload(ctx context.Context, service *model.Service)
 }
 
 func (r *serviceRepository) GetAll(ctx context.Context) ([]*model.Service, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{"order", 1}}))
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "order", Value: 1}}))
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +149,269 @@ func (r *serviceRepository) GetAll(ctx context.Context) ([]*model.Service, error
 	}
 	return services, nil
 }
+
+func (r *serviceRepository) Find(ctx context.Context, filter ServiceFilter, opts ListOptions) ([]*model.Service, int64, error) {
+	query := filter.toBson()
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts.findOptions("order"))
+	if err != nil {
+		return nil, 0, err
+	}
+	var services []*model.Service
+	if err := cursor.All(ctx, &services); err != nil {
+		return nil, 0, err
+	}
+	return services, total, nil
+}
+
+func (r *serviceRepository) DistinctGroupIDs(ctx context.Context, filter ServiceFilter) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "group_id", filter.toBson())
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *serviceRepository) GetByIDs(ctx context.Context, ids []string) ([]*model.Service, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, oid)
+		}
+	}
+	if len(objectIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var services []*model.Service
+	if err := cursor.All(ctx, &services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// TextSearch tìm theo text index, kết quả sắp xếp theo textScore giảm dần
+func (r *serviceRepository) TextSearch(ctx context.Context, text string, filter ServiceFilter, limit int) ([]*model.Service, error) {
+	query := filter.toBson()
+	query["$text"] = bson.M{"$search": text}
+
+	opts := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	var services []*model.Service
+	if err := cursor.All(ctx, &services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+func (r *serviceRepository) HasOrganization(ctx context.Context, organizationID string) (bool, error) {
+	if organizationID == "" {
+		return false, nil
+	}
+	count, err := r.collection.CountDocuments(ctx, bson.M{"organization_id": organizationID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *serviceRepository) UsesIcon(ctx context.Context, assetID string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"icon.image_key": assetID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *serviceRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "tags", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "url", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("service_text_search").
+				SetDefaultLanguage("none").
+				SetWeights(bson.M{"title": 10, "tags": 5, "description": 2, "url": 1}),
+		},
+		{Keys: bson.D{{Key: "health.status", Value: 1}}},
+		{Keys: bson.D{{Key: "organization_id", Value: 1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "ownership.owner_team", Value: 1}}},
+		{Keys: bson.D{{Key: "ownership.owner_staff_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "depends_on", Value: 1}}},
+	})
+	return err
+}
+
+func (r *serviceRepository) GetByID(ctx context.Context, id string) (*model.Service, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var service model.Service
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&service); err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+func (r *serviceRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *serviceRepository) SetTranslation(ctx context.Context, id, locale string, translation model.Translation) error {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"translations." + locale: translation, "updated_at": time.Now()},
+	})
+}
+
+func (r *serviceRepository) DeleteTranslation(ctx context.Context, id, locale string) error {
+	return r.update(ctx, id, bson.M{
+		"$unset": bson.M{"translations." + locale: ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	})
+}
+
+func (r *serviceRepository) SetTags(ctx context.Context, id string, tags []string) error {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"tags": tags, "updated_at": time.Now()},
+	})
+}
+
+func (r *serviceRepository) TagCounts(ctx context.Context, filter ServiceFilter) ([]TagCount, error) {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter.toBson()}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var counts []TagCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// SetSSO sso nil là xoá cấu hình
+func (r *serviceRepository) SetSSO(ctx context.Context, id string, sso *model.SSOConfig) error {
+	if sso == nil {
+		return r.update(ctx, id, bson.M{
+			"$unset": bson.M{"sso": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		})
+	}
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"sso": sso, "updated_at": time.Now()},
+	})
+}
+
+func (r *serviceRepository) SetHealthCheck(ctx context.Context, id string, cfg *model.HealthCheckConfig) error {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"health_check": cfg, "updated_at": time.Now()},
+	})
+}
+
+func (r *serviceRepository) SetOwnership(ctx context.Context, id string, ownership *model.Ownership) error {
+	if ownership == nil {
+		return r.update(ctx, id, bson.M{
+			"$unset": bson.M{"ownership": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		})
+	}
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"ownership": ownership, "updated_at": time.Now()},
+	})
+}
+
+func (r *serviceRepository) SetDependencies(ctx context.Context, id string, dependsOn []string) error {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"depends_on": dependsOn, "updated_at": time.Now()},
+	})
+}
+
+// SetHealth lưu kết quả probe, changed_at chỉ đổi khi status khác lần trước.
+// Không đụng updated_at vì đây không phải thay đổi của admin
+func (r *serviceRepository) SetHealth(ctx context.Context, id string, state model.HealthState) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+
+	changedAt := bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{"$health.status", state.Status}},
+		"$health.changed_at",
+		state.LastChecked,
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"health": bson.M{
+			"status":       state.Status,
+			"last_checked": state.LastChecked,
+			"status_code":  state.StatusCode,
+			"latency_ms":   state.LatencyMs,
+			"error":        state.Error,
+			"changed_at":   changedAt,
+		}}}},
+	})
+	return err
+}
+
+// update trả về mongo.ErrNoDocuments nếu không có service nào khớp id
+func (r *serviceRepository) update(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SmartCollectionFilter field rỗng thì bỏ qua
+type SmartCollectionFilter struct {
+	OrganizationID string // gồm cả collection dùng chung
+	Tag            string // collection có tag này trong query
+}
+
+func (f SmartCollectionFilter) toBson() bson.M {
+	query := bson.M{}
+	if f.OrganizationID != "" {
+		query["organization_id"] = bson.M{"$in": bson.A{f.OrganizationID, "", nil}}
+	}
+	if f.Tag != "" {
+		query["query.tags"] = f.Tag
+	}
+	return query
+}
+
+type SmartCollectionRepository interface {
+	Create(ctx context.Context, collection *model.SmartCollection) error
+	Update(ctx context.Context, collection *model.SmartCollection) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.SmartCollection, error)
+	Find(ctx context.Context, filter SmartCollectionFilter) ([]*model.SmartCollection, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type smartCollectionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSmartCollectionRepository(collection *mongo.Collection) SmartCollectionRepository {
+	return &smartCollectionRepository{
+		collection: collection,
+	}
+}
+
+func (r *smartCollectionRepository) Create(ctx context.Context, collection *model.SmartCollection) error {
+	now := time.Now()
+	collection.CreatedAt = now
+	collection.UpdatedAt = now
+	_, err := r.collection.InsertOne(ctx, collection)
+	return err
+}
+
+func (r *smartCollectionRepository) Update(ctx context.Context, collection *model.SmartCollection) error {
+	collection.UpdatedAt = time.Now()
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": collection.ID}, collection)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *smartCollectionRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *smartCollectionRepository) GetByID(ctx context.Context, id string) (*model.SmartCollection, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	var collection model.SmartCollection
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// Find sắp theo order
+func (r *smartCollectionRepository) Find(ctx context.Context, filter SmartCollectionFilter) ([]*model.SmartCollection, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter.toBson(), opts)
+	if err != nil {
+		return nil, err
+	}
+	var collections []*model.SmartCollection
+	if err := cursor.All(ctx, &collections); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *smartCollectionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "organization_id", Value: 1}, {Key: "order", Value: 1}}},
+		{Keys: bson.D{{Key: "query.tags", Value: 1}}},
+	})
+	return err
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SSONonceRepository interface {
+	Save(ctx context.Context, nonce *model.SSONonce) error
+	// Consume xoá nonce, trả về false nếu nonce không tồn tại, đã dùng hoặc đã hết hạn
+	Consume(ctx context.Context, nonce string) (bool, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type ssoNonceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSSONonceRepository(collection *mongo.Collection) SSONonceRepository {
+	return &ssoNonceRepository{
+		collection: collection,
+	}
+}
+
+func (r *ssoNonceRepository) Save(ctx context.Context, nonce *model.SSONonce) error {
+	_, err := r.collection.InsertOne(ctx, nonce)
+	return err
+}
+
+func (r *ssoNonceRepository) Consume(ctx context.Context, nonce string) (bool, error) {
+	// DeleteOne là thao tác atomic nên hai request cùng nonce chỉ một request thành công
+	res, err := r.collection.DeleteOne(ctx, bson.M{
+		"_id":        nonce,
+		"expires_at": bson.M{"$gt": time.Now()},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount == 1, nil
+}
+
+// EnsureIndexes TTL index để Mongo tự dọn nonce hết hạn
+func (r *ssoNonceRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor chạy fn trong một transaction, repository nhận ctx của fn sẽ ghi vào transaction đó
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type mongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewTransactor transaction cần MongoDB replica set, client nil thì chạy fn trực tiếp không transaction
+func NewTransactor(client *mongo.Client) Transactor {
+	return &mongoTransactor{
+		client: client,
+	}
+}
+
+func (t *mongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t.client == nil {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
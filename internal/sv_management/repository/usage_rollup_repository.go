@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UsageFilter khoảng ngày [From, To] dạng yyyy-mm-dd, Role rỗng là mọi role
+type UsageFilter struct {
+	From           string
+	To             string
+	OrganizationID string
+	Role           string
+	ServiceID      string
+}
+
+func (f UsageFilter) toBson() bson.M {
+	query := bson.M{"role": f.Role}
+
+	day := bson.M{}
+	if f.From != "" {
+		day["$gte"] = f.From
+	}
+	if f.To != "" {
+		day["$lte"] = f.To
+	}
+	if len(day) > 0 {
+		query["day"] = day
+	}
+	if f.OrganizationID != "" {
+		query["organization_id"] = f.OrganizationID
+	}
+	if f.ServiceID != "" {
+		query["service_id"] = f.ServiceID
+	}
+	return query
+}
+
+type UsageRollupRepository interface {
+	// CollectionName để job rollup $merge vào
+	CollectionName() string
+	LatestDay(ctx context.Context) (string, error)
+	UsageByService(ctx context.Context, filter UsageFilter, limit int) ([]*model.ServiceUsage, error)
+	DailyUsage(ctx context.Context, filter UsageFilter) ([]*model.DailyUsage, error)
+	UsedServiceIDs(ctx context.Context, filter UsageFilter) ([]string, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type usageRollupRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUsageRollupRepository(collection *mongo.Collection) UsageRollupRepository {
+	return &usageRollupRepository{
+		collection: collection,
+	}
+}
+
+func (r *usageRollupRepository) CollectionName() string {
+	return r.collection.Name()
+}
+
+// LatestDay ngày mới nhất đã có rollup, rỗng nếu chưa rollup lần nào
+func (r *usageRollupRepository) LatestDay(ctx context.Context) (string, error) {
+	var rollup model.UsageRollup
+	err := r.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "day", Value: -1}})).Decode(&rollup)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return rollup.Day, nil
+}
+
+// UsageByService tổng click và số user khác nhau của từng service, sắp theo click giảm dần.
+// limit <= 0 là lấy hết
+func (r *usageRollupRepository) UsageByService(ctx context.Context, filter UsageFilter, limit int) ([]*model.ServiceUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter.toBson()}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$service_id",
+			"clicks":   bson.M{"$sum": "$clicks"},
+			"user_ids": bson.M{"$push": "$user_ids"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"clicks":       1,
+			"unique_users": bson.M{"$size": unionUserIDs},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "clicks", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	var result []*model.ServiceUsage
+	if err := r.aggregate(ctx, pipeline, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DailyUsage click và số user active theo từng ngày, sắp theo ngày tăng dần
+func (r *usageRollupRepository) DailyUsage(ctx context.Context, filter UsageFilter) ([]*model.DailyUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter.toBson()}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$day",
+			"clicks":   bson.M{"$sum": "$clicks"},
+			"user_ids": bson.M{"$push": "$user_ids"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"clicks":       1,
+			"active_users": bson.M{"$size": unionUserIDs},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	var result []*model.DailyUsage
+	if err := r.aggregate(ctx, pipeline, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *usageRollupRepository) UsedServiceIDs(ctx context.Context, filter UsageFilter) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "service_id", filter.toBson())
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *usageRollupRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "role", Value: 1}, {Key: "day", Value: -1}, {Key: "organization_id", Value: 1}}},
+		{Keys: bson.D{{Key: "service_id", Value: 1}, {Key: "day", Value: -1}}},
+	})
+	return err
+}
+
+func (r *usageRollupRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline, result interface{}) error {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, result)
+}
+
+// unionUserIDs gộp mảng user_ids của nhiều bản ghi rollup thành tập user khác nhau
+var unionUserIDs = bson.M{"$reduce": bson.M{
+	"input":        "$user_ids",
+	"initialValue": bson.A{},
+	"in":           bson.M{"$setUnion": bson.A{"$$value", "$$this"}},
+}}
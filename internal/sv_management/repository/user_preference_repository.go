@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserPreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID string) (*model.UserPreference, error)
+	AddFavorite(ctx context.Context, userID, serviceID string) error
+	RemoveFavorite(ctx context.Context, userID, serviceID string) error
+	SetFavorites(ctx context.Context, userID string, serviceIDs []string) error
+	SetOrders(ctx context.Context, userID string, groupOrders, serviceOrders map[string]int) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+type userPreferenceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUserPreferenceRepository(collection *mongo.Collection) UserPreferenceRepository {
+	return &userPreferenceRepository{
+		collection: collection,
+	}
+}
+
+// GetByUserID user chưa có tuỳ chỉnh thì trả về preference rỗng
+func (r *userPreferenceRepository) GetByUserID(ctx context.Context, userID string) (*model.UserPreference, error) {
+	var pref model.UserPreference
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&pref)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &model.UserPreference{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// AddFavorite thêm vào cuối danh sách, đã có thì giữ nguyên vị trí
+func (r *userPreferenceRepository) AddFavorite(ctx context.Context, userID, serviceID string) error {
+	return r.upsert(ctx, userID, bson.M{"$addToSet": bson.M{"favorites": serviceID}})
+}
+
+func (r *userPreferenceRepository) RemoveFavorite(ctx context.Context, userID, serviceID string) error {
+	return r.upsert(ctx, userID, bson.M{"$pull": bson.M{"favorites": serviceID}})
+}
+
+func (r *userPreferenceRepository) SetFavorites(ctx context.Context, userID string, serviceIDs []string) error {
+	return r.upsert(ctx, userID, bson.M{"$set": bson.M{"favorites": serviceIDs}})
+}
+
+func (r *userPreferenceRepository) SetOrders(ctx context.Context, userID string, groupOrders, serviceOrders map[string]int) error {
+	return r.upsert(ctx, userID, bson.M{"$set": bson.M{
+		"group_orders":   groupOrders,
+		"service_orders": serviceOrders,
+	}})
+}
+
+func (r *userPreferenceRepository) upsert(ctx context.Context, userID string, update bson.M) error {
+	now := time.Now()
+	if set, ok := update["$set"].(bson.M); ok {
+		set["updated_at"] = now
+	} else {
+		update["$set"] = bson.M{"updated_at": now}
+	}
+	update["$setOnInsert"] = bson.M{"created_at": now}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"user_id": userID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *userPreferenceRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
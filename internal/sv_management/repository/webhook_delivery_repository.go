@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID string
+	Status         string
+}
+
+func (f WebhookDeliveryFilter) toBson() bson.M {
+	query := bson.M{}
+	if f.SubscriptionID != "" {
+		query["subscription_id"] = f.SubscriptionID
+	}
+	if f.Status != "" {
+		query["status"] = f.Status
+	}
+	return query
+}
+
+type WebhookDeliveryRepository interface {
+	InsertMany(ctx context.Context, deliveries []*model.WebhookDelivery) error
+	// Claim lấy một delivery đến hạn và dời next_attempt_at thêm lease để instance khác không gửi trùng
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	// RecordAttempt lưu kết quả lần gửi, next nil khi delivery đã xong (thành công hoặc dead)
+	RecordAttempt(ctx context.Context, id primitive.ObjectID, attempt model.WebhookAttempt, status string, next *time.Time) error
+	// Requeue đưa delivery dead về pending để gửi lại ngay
+	Requeue(ctx context.Context, id string) error
+	Find(ctx context.Context, filter WebhookDeliveryFilter, opts ListOptions) ([]*model.WebhookDelivery, int64, error)
+	DeleteBySubscription(ctx context.Context, subscriptionID string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+type webhookDeliveryRepository struct {
+	collection *mongo.Collection
+	retention  time.Duration
+}
+
+// NewWebhookDeliveryRepository delivery quá retention bị TTL index xoá
+func NewWebhookDeliveryRepository(collection *mongo.Collection, retention time.Duration) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		collection: collection,
+		retention:  retention,
+	}
+}
+
+func (r *webhookDeliveryRepository) InsertMany(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	docs := make([]any, len(deliveries))
+	for i, d := range deliveries {
+		if d.ID.IsZero() {
+			d.ID = primitive.NewObjectID()
+		}
+		docs[i] = d
+	}
+	// tailer của mọi instance cùng chuyển một event nên delivery trùng (subscription, event) bị bỏ qua
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *webhookDeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery model.WebhookDelivery
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{
+		"status":          model.DeliveryStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}, bson.M{
+		"$set": bson.M{"next_attempt_at": now.Add(lease)},
+	}, opts).Decode(&delivery)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) RecordAttempt(ctx context.Context, id primitive.ObjectID, attempt model.WebhookAttempt, status string, next *time.Time) error {
+	update := bson.M{
+		"$push": bson.M{"attempts": attempt},
+		"$set":  bson.M{"status": status, "updated_at": time.Now()},
+	}
+	if next != nil {
+		update["$set"].(bson.M)["next_attempt_at"] = *next
+	} else {
+		update["$unset"] = bson.M{"next_attempt_at": ""}
+	}
+
+	_, err := r.collection.UpdateByID(ctx, id, update)
+	return err
+}
+
+func (r *webhookDeliveryRepository) Requeue(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    objectID,
+		"status": model.DeliveryStatusDead,
+	}, bson.M{
+		"$set": bson.M{
+			"status":          model.DeliveryStatusPending,
+			"next_attempt_at": now,
+			"updated_at":      now,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) Find(ctx context.Context, filter WebhookDeliveryFilter, opts ListOptions) ([]*model.WebhookDelivery, int64, error) {
+	query := filter.toBson()
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts.SortDesc = true
+	cursor, err := r.collection.Find(ctx, query, opts.findOptions("created_at"))
+	if err != nil {
+		return nil, 0, err
+	}
+	var deliveries []*model.WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (r *webhookDeliveryRepository) DeleteBySubscription(ctx context.Context, subscriptionID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"subscription_id": subscriptionID})
+	return err
+}
+
+func (r *webhookDeliveryRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "subscription_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{
+			Keys:    bson.D{{Key: "subscription_id", Value: 1}, {Key: "event_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(r.retention.Seconds())),
+		},
+	})
+	return err
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription *model.WebhookSubscription) error
+	Update(ctx context.Context, subscription *model.WebhookSubscription) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]*model.WebhookSubscription, error)
+	FindActive(ctx context.Context) ([]*model.WebhookSubscription, error)
+}
+
+type webhookRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository(collection *mongo.Collection) WebhookRepository {
+	return &webhookRepository{
+		collection: collection,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, subscription *model.WebhookSubscription) error {
+	if subscription.ID.IsZero() {
+		subscription.ID = primitive.NewObjectID()
+	}
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = subscription.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, subscription)
+	return err
+}
+
+func (r *webhookRepository) Update(ctx context.Context, subscription *model.WebhookSubscription) error {
+	subscription.UpdatedAt = time.Now()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": subscription.ID}, subscription)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription model.WebhookSubscription
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *webhookRepository) FindActive(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return r.find(ctx, bson.M{"active": true})
+}
+
+func (r *webhookRepository) find(ctx context.Context, query bson.M) ([]*model.WebhookSubscription, error) {
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var subscriptions []*model.WebhookSubscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,20 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAnalyticsRoutes(r *gin.Engine, ah *handler.AnalyticsHandler) {
+	// Admin routes
+	analytics := r.Group("/api/v1/admin/analytics", middleware.Secured(), middleware.RequireAdmin())
+	{
+		analytics.GET("/top-services", ah.TopServices)
+		analytics.GET("/daily-active-users", ah.DailyActiveUsers)
+		analytics.GET("/never-used", ah.NeverUsed)
+		analytics.GET("/trends", ah.Trends)
+		analytics.POST("/rollup", ah.RunRollup)
+	}
+}
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAnnouncementRoutes(r *gin.Engine, ah *handler.AnnouncementHandler) {
+	// Admin routes
+	announcements := r.Group("/api/v1/admin/announcements", middleware.Secured(), middleware.RequireAdmin())
+	{
+		announcements.POST("", ah.Create)
+		announcements.GET("", ah.List)
+		announcements.PUT("/:id", ah.Update)
+		announcements.DELETE("/:id", ah.Delete)
+	}
+}
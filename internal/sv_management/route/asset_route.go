@@ -0,0 +1,20 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAssetRoutes(r *gin.Engine, ah *handler.AssetHandler) {
+	// Public: thẻ <img> không gửi được Authorization header
+	r.GET("/api/v1/assets/:id", ah.Serve)
+
+	// Admin routes
+	assets := r.Group("/api/v1/admin/assets", middleware.Secured(), middleware.RequireAdmin())
+	{
+		assets.POST("", ah.Upload)
+		assets.DELETE("/:id", ah.Delete)
+	}
+}
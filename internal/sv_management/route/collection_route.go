@@ -0,0 +1,21 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCollectionRoutes(r *gin.Engine, ch *handler.SmartCollectionHandler) {
+	// Admin routes
+	collections := r.Group("/api/v1/admin/collections", middleware.Secured(), middleware.RequireAdmin())
+	{
+		collections.POST("", ch.Create)
+		collections.GET("", ch.List)
+		collections.GET("/:id", ch.Get)
+		collections.GET("/:id/services", ch.Services)
+		collections.PUT("/:id", ch.Update)
+		collections.DELETE("/:id", ch.Delete)
+	}
+}
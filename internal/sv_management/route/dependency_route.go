@@ -0,0 +1,18 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDependencyRoutes(r *gin.Engine, dh *handler.DependencyHandler) {
+	// Admin routes
+	services := r.Group("/api/v1/admin/services", middleware.Secured(), middleware.RequireAdmin())
+	{
+		services.GET("/dependencies/graph", dh.Graph)
+		services.PUT("/:id/dependencies", dh.SetDependencies)
+		services.GET("/:id/impact", dh.Impact)
+	}
+}
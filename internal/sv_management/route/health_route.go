@@ -0,0 +1,27 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHealthRoutes(r *gin.Engine, hh *handler.HealthHandler) {
+	// Admin routes
+	admin := r.Group("/api/v1/admin", middleware.Secured(), middleware.RequireAdmin())
+
+	healthGroup := admin.Group("/health")
+	{
+		healthGroup.GET("/failing", hh.Failing)
+		healthGroup.POST("/run", hh.RunAll)
+	}
+
+	services := admin.Group("/services/:id/health")
+	{
+		services.GET("", hh.History)
+		services.PUT("/config", hh.Configure)
+		services.POST("/check", hh.CheckNow)
+		services.PUT("/status", hh.MarkStatus)
+	}
+}
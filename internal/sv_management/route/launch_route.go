@@ -0,0 +1,13 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterLaunchRoutes(r *gin.Engine, lh *handler.LaunchHandler) {
+	// link trong catalog mở bằng điều hướng của trình duyệt, không gửi được header Authorization nên nhận thêm token qua query
+	r.GET("/go/:serviceId", middleware.QueryToken("access_token"), middleware.Secured(), middleware.RequireUser(), lh.Launch)
+}
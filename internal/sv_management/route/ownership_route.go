@@ -0,0 +1,21 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterOwnershipRoutes(r *gin.Engine, oh *handler.OwnershipHandler) {
+	// User routes
+	r.GET("/api/v1/services/:serviceId/support", middleware.Secured(), middleware.RequireUser(), oh.Support)
+
+	// Admin routes
+	services := r.Group("/api/v1/admin/services", middleware.Secured(), middleware.RequireAdmin())
+	{
+		services.GET("/owners", oh.ServicesByOwner)
+		services.PUT("/:id/ownership", oh.SetOwnership)
+		services.DELETE("/:id/ownership", oh.ClearOwnership)
+	}
+}
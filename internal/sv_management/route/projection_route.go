@@ -0,0 +1,19 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterProjectionRoutes(r *gin.Engine, ph *handler.ProjectionHandler) {
+	// Admin routes
+	admin := r.Group("/api/v1/admin", middleware.Secured(), middleware.RequireAdmin())
+	{
+		admin.GET("/projections/catalog", ph.Status)
+		admin.POST("/projections/catalog/replay", ph.Replay)
+		admin.GET("/projections/catalog/views", ph.Views)
+		admin.GET("/streams/:aggregateType/:id", ph.History)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterServiceRoutes(r *gin.Engine, sh *handler.ServiceHandler, sgh *handler.ServiceGroupHandler) {
+func RegisterServiceRoutes(r *gin.Engine, sh *handler.ServiceHandler, sgh *handler.ServiceGroupHandler, sch *handler.SearchHandler) {
 	// Admin routes
 	adminGroup := r.Group("/api/v1/admin", middleware.Secured(), middleware.RequireAdmin())
 
@@ -15,12 +15,31 @@ func RegisterServiceRoutes(r *gin.Engine, sh *handler.ServiceHandler, sgh *handl
 	services := adminGroup.Group("/services")
 	{
 		services.POST("", sh.Upload)
-		services.GET("", sh.GetServices)
+		services.GET("", middleware.ConditionalGet(catalogCacheControl), sh.GetServices)
+		services.GET("/list", sh.ListServices)
+		services.DELETE("/:id", sh.Delete)
+
+		// Cache routes
+		services.GET("/cache/stats", sh.CacheStats)
+		services.POST("/cache/invalidate", sh.InvalidateCache)
+
+		// Tag routes
+		services.GET("/tags", sh.ListTags)
+		services.PUT("/tags/:tag", sh.RenameTag)
+		services.DELETE("/tags/:tag", sh.DeleteTag)
+		services.PUT("/:id/tags", sh.SetTags)
+
+		// Search routes
+		services.GET("/search", sch.Search)
+		services.POST("/search/reindex", sch.Reindex)
 
 		// Service group routes
 		groups := services.Group("/groups")
 		{
 			groups.POST("", sgh.Upload)
+			groups.PUT("/reorder", sgh.Reorder)
+			groups.PUT("/:id/move", sgh.Move)
+			groups.DELETE("/:id", sgh.Delete)
 		}
 	}
 }
@@ -0,0 +1,21 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSSORoutes(r *gin.Engine, ssh *handler.SSOHandler) {
+	// Admin routes
+	admin := r.Group("/api/v1/admin/services/:id/sso", middleware.Secured(), middleware.RequireAdmin())
+	{
+		admin.GET("", ssh.GetConfig)
+		admin.PUT("", ssh.Configure)
+		admin.DELETE("", ssh.Disable)
+	}
+
+	// Đối tác gọi server-to-server, token tự chứng thực nên không cần JWT của mình
+	r.POST("/api/v1/sso/verify", ssh.Verify)
+}
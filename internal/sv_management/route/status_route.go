@@ -0,0 +1,16 @@
+package route
+
+import (
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStatusRoutes(r *gin.Engine, sph *handler.StatusPageHandler) {
+	// Public routes
+	status := r.Group("/api/v1/status")
+	{
+		status.GET("", sph.GetStatus)
+		status.GET("/embed", sph.GetStatusEmbed)
+	}
+}
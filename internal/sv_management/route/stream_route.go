@@ -0,0 +1,13 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStreamRoutes(r *gin.Engine, csh *handler.CatalogStreamHandler) {
+	// EventSource của trình duyệt không gửi được header Authorization nên nhận thêm token qua query
+	r.GET("/api/v1/services/stream", middleware.QueryToken("access_token"), middleware.Secured(), middleware.RequireUser(), csh.Stream)
+}
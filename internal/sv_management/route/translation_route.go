@@ -0,0 +1,21 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTranslationRoutes(r *gin.Engine, th *handler.TranslationHandler) {
+	// Admin routes
+	services := r.Group("/api/v1/admin/services", middleware.Secured(), middleware.RequireAdmin())
+	{
+		services.GET("/translations/missing", th.GetMissing)
+		services.PUT("/:id/translations/:locale", th.SetServiceTranslation)
+		services.DELETE("/:id/translations/:locale", th.DeleteServiceTranslation)
+
+		services.PUT("/groups/:id/translations/:locale", th.SetGroupTranslation)
+		services.DELETE("/groups/:id/translations/:locale", th.DeleteGroupTranslation)
+	}
+}
@@ -0,0 +1,31 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// catalogCacheControl client được lưu catalog nhưng phải hỏi lại server (If-None-Match) trước khi dùng
+const catalogCacheControl = "private, no-cache"
+
+func RegisterUserRoutes(r *gin.Engine, uch *handler.UserCatalogHandler, csh *handler.CatalogSyncHandler, sch *handler.SearchHandler) {
+	// User routes
+	userGroup := r.Group("/api/v1", middleware.Secured(), middleware.RequireUser())
+
+	userGroup.GET("/services", middleware.ConditionalGet(catalogCacheControl), uch.GetCatalog)
+	userGroup.GET("/services/changes", csh.Changes)
+	userGroup.GET("/services/search", sch.SearchCatalog)
+
+	me := userGroup.Group("/me")
+	{
+		me.GET("/favorites", uch.GetFavorites)
+		me.PUT("/favorites/order", uch.ReorderFavorites)
+		me.PUT("/favorites/:serviceId", uch.AddFavorite)
+		me.DELETE("/favorites/:serviceId", uch.RemoveFavorite)
+
+		me.PUT("/ordering", uch.SetOrdering)
+		me.DELETE("/ordering", uch.ResetOrdering)
+	}
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWebhookRoutes(r *gin.Engine, wh *handler.WebhookHandler) {
+	// Admin routes
+	webhooks := r.Group("/api/v1/admin/webhooks", middleware.Secured(), middleware.RequireAdmin())
+	{
+		webhooks.POST("", wh.Create)
+		webhooks.GET("", wh.List)
+		webhooks.GET("/dead-letters", wh.DeadLetters)
+		webhooks.POST("/deliveries/:deliveryId/retry", wh.Redeliver)
+		webhooks.GET("/:id", wh.Get)
+		webhooks.PUT("/:id", wh.Update)
+		webhooks.DELETE("/:id", wh.Delete)
+		webhooks.GET("/:id/deliveries", wh.Deliveries)
+	}
+}
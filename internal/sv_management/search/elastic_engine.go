@@ -0,0 +1,200 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"services-management/pkg/constants"
+	"strings"
+	"time"
+)
+
+type elasticEngine struct {
+	baseUrl    string
+	index      string
+	httpClient *http.Client
+}
+
+// NewElasticEngine search qua Elasticsearch REST API, fuzziness AUTO để chịu lỗi chính tả
+func NewElasticEngine(baseUrl, index string) Engine {
+	return &elasticEngine{
+		baseUrl:    strings.TrimRight(baseUrl, "/"),
+		index:      index,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type elasticSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			ID        string              `json:"_id"`
+			Score     float64             `json:"_score"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (e *elasticEngine) Search(ctx context.Context, query Query) ([]Hit, error) {
+	filters := []any{}
+	if query.OrganizationID != "" {
+		filters = append(filters, matchOrMissing("organization_id", query.OrganizationID))
+	}
+	if query.Roles != nil {
+		filters = append(filters, matchAnyOrMissing("roles", query.Roles))
+	}
+	if query.Status == string(constants.ServiceStatusActive) {
+		// document chưa có status là service cũ, coi như active
+		filters = append(filters, matchOrMissing("status", query.Status))
+	} else if query.Status != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{"status": query.Status}})
+	}
+
+	body := map[string]any{
+		"size": query.Size,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": map[string]any{
+					"multi_match": map[string]any{
+						"query":     query.Text,
+						"fields":    []string{"title^3", "tags^2", "group_title^1.5", "description", "url"},
+						"fuzziness": "AUTO",
+						"type":      "best_fields",
+					},
+				},
+				"filter": filters,
+			},
+		},
+		"highlight": map[string]any{
+			"pre_tags":  []string{"<em>"},
+			"post_tags": []string{"</em>"},
+			"fields": map[string]any{
+				"title":       map[string]any{},
+				"tags":        map[string]any{},
+				"group_title": map[string]any{},
+				"description": map[string]any{},
+				"url":         map[string]any{},
+			},
+		},
+	}
+
+	data, err := e.do(ctx, http.MethodPost, "/"+e.index+"/_search", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp elasticSearchResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal elastic response fail: %w", err)
+	}
+
+	hits := make([]Hit, 0, len(resp.Hits.Hits))
+	for _, h := range resp.Hits.Hits {
+		hits = append(hits, Hit{ID: h.ID, Score: h.Score, Highlights: h.Highlight})
+	}
+	return hits, nil
+}
+
+func (e *elasticEngine) Index(ctx context.Context, doc Document) error {
+	_, err := e.do(ctx, http.MethodPut, "/"+e.index+"/_doc/"+url.PathEscape(doc.ID), doc)
+	return err
+}
+
+func (e *elasticEngine) Delete(ctx context.Context, id string) error {
+	_, err := e.do(ctx, http.MethodDelete, "/"+e.index+"/_doc/"+url.PathEscape(id), nil)
+	return err
+}
+
+// Reindex xoá index cũ và index lại toàn bộ documents
+func (e *elasticEngine) Reindex(ctx context.Context, docs []Document) error {
+	if _, err := e.do(ctx, http.MethodDelete, "/"+e.index+"?ignore_unavailable=true", nil); err != nil {
+		return err
+	}
+
+	mapping := map[string]any{
+		"mappings": map[string]any{
+			"properties": map[string]any{
+				"organization_id": map[string]any{"type": "keyword"},
+				"group_id":        map[string]any{"type": "keyword"},
+				"roles":           map[string]any{"type": "keyword"},
+				"status":          map[string]any{"type": "keyword"},
+				"title":           map[string]any{"type": "text"},
+				"group_title":     map[string]any{"type": "text"},
+				"description":     map[string]any{"type": "text"},
+				"url":             map[string]any{"type": "text"},
+				"tags":            map[string]any{"type": "text"},
+			},
+		},
+	}
+	if _, err := e.do(ctx, http.MethodPut, "/"+e.index, mapping); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if err := e.Index(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *elasticEngine) do(ctx context.Context, method, path string, body any) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal body failed: %v", err)
+		}
+		reqBody = bytes.NewReader(jsonBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, e.baseUrl+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("elastic call failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %v", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("elastic error: %s: %s", resp.Status, string(data))
+	}
+	return data, nil
+}
+
+// matchOrMissing khớp giá trị hoặc document không có field (dùng chung cho mọi org/role)
+func matchOrMissing(field, value string) map[string]any {
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []any{
+				map[string]any{"term": map[string]any{field: value}},
+				map[string]any{"bool": map[string]any{"must_not": map[string]any{"exists": map[string]any{"field": field}}}},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// matchAnyOrMissing field khớp một trong values hoặc không có field
+func matchAnyOrMissing(field string, values []string) map[string]any {
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []any{
+				map[string]any{"terms": map[string]any{field: values}},
+				map[string]any{"bool": map[string]any{"must_not": map[string]any{"exists": map[string]any{"field": field}}}},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
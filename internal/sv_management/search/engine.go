@@ -0,0 +1,63 @@
+package search
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"services-management/pkg/constants"
+)
+
+// Document dữ liệu của 1 service dùng để index và search
+type Document struct {
+	ID             string   `json:"id"`
+	OrganizationID string   `json:"organization_id,omitempty"`
+	GroupID        string   `json:"group_id"`
+	GroupTitle     string   `json:"group_title"`
+	Title          string   `json:"title"`
+	Url            string   `json:"url"`
+	Description    string   `json:"description"`
+	Tags           []string `json:"tags"`
+	Roles          []string `json:"roles,omitempty"`
+	Status         string   `json:"status"`
+}
+
+type Query struct {
+	Text           string
+	OrganizationID string
+	Roles          []string // nil là không lọc, rỗng là chỉ lấy service không giới hạn role
+	Status         string
+	Size           int
+}
+
+// Hit 1 kết quả search, Highlights là các đoạn match đã bọc <em></em> theo từng field
+type Hit struct {
+	ID         string
+	Score      float64
+	Highlights map[string][]string
+}
+
+type Engine interface {
+	Search(ctx context.Context, query Query) ([]Hit, error)
+	Index(ctx context.Context, doc Document) error
+	Delete(ctx context.Context, id string) error
+	Reindex(ctx context.Context, docs []Document) error
+}
+
+func NewDocument(service *model.Service, groupTitle string) Document {
+	// service cũ chưa có status được index là active
+	status := service.Status
+	if status == "" {
+		status = string(constants.ServiceStatusActive)
+	}
+	return Document{
+		ID:             service.ID.Hex(),
+		OrganizationID: service.OrganizationID,
+		GroupID:        service.GroupID,
+		GroupTitle:     groupTitle,
+		Title:          service.Title,
+		Url:            service.Url,
+		Description:    service.Description,
+		Tags:           service.Tags,
+		Roles:          service.Roles,
+		Status:         status,
+	}
+}
@@ -0,0 +1,246 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	scoreExact     = 1.0
+	scorePrefix    = 0.9
+	scoreContains  = 0.7
+	scoreFuzzyPart = 0.6
+	scoreFuzzy     = 0.5
+
+	minFuzzyPrefixLength = 4
+)
+
+// Trọng số theo field, title quan trọng nhất
+var fieldWeights = map[string]float64{
+	"title":       3,
+	"tags":        2,
+	"group_title": 1.5,
+	"description": 1,
+	"url":         1,
+}
+
+type token struct {
+	text  []rune // đã lowercase và bỏ dấu
+	runes []int  // vị trí byte của từng rune trong chuỗi gốc
+	end   int
+}
+
+// tokenMatch kết quả so khớp 1 query token với 1 field token
+type tokenMatch struct {
+	score float64
+	from  int // khoảng rune được highlight trong field token
+	to    int
+}
+
+type matcher struct {
+	terms [][]rune
+}
+
+func newMatcher(text string) *matcher {
+	m := &matcher{}
+	for _, t := range tokenize(text) {
+		m.terms = append(m.terms, t.text)
+	}
+	return m
+}
+
+// match tính điểm của document, mọi query term đều phải match ít nhất 1 field
+func (m *matcher) match(doc Document) (float64, map[string][]string) {
+	if len(m.terms) == 0 {
+		return 0, nil
+	}
+
+	fields := map[string][]string{
+		"title":       {doc.Title},
+		"tags":        doc.Tags,
+		"group_title": {doc.GroupTitle},
+		"description": {doc.Description},
+		"url":         {doc.Url},
+	}
+
+	total := 0.0
+	highlights := make(map[string][]string)
+	for _, term := range m.terms {
+		best := 0.0
+		for field, values := range fields {
+			for _, value := range values {
+				if s := matchValue(term, value) * fieldWeights[field]; s > best {
+					best = s
+				}
+			}
+		}
+		if best == 0 {
+			return 0, nil
+		}
+		total += best
+	}
+
+	for field, values := range fields {
+		for _, value := range values {
+			if hl, ok := highlight(m.terms, value); ok {
+				highlights[field] = append(highlights[field], hl)
+			}
+		}
+	}
+	return total, highlights
+}
+
+func matchValue(term []rune, value string) float64 {
+	best := 0.0
+	for _, t := range tokenize(value) {
+		if tm := matchToken(term, t.text); tm.score > best {
+			best = tm.score
+		}
+	}
+	return best
+}
+
+func matchToken(term, word []rune) tokenMatch {
+	if len(term) == 0 || len(word) == 0 {
+		return tokenMatch{}
+	}
+	if string(term) == string(word) {
+		return tokenMatch{score: scoreExact, from: 0, to: len(word)}
+	}
+	if idx := runeIndex(word, term); idx == 0 {
+		return tokenMatch{score: scorePrefix, from: 0, to: len(term)}
+	} else if idx > 0 {
+		return tokenMatch{score: scoreContains, from: idx, to: idx + len(term)}
+	}
+
+	maxEdits := allowedEdits(len(term))
+	if maxEdits == 0 {
+		return tokenMatch{}
+	}
+	// "grdae" vs "gradebook": so với phần đầu của word cùng độ dài, term quá ngắn thì dễ match nhầm
+	if len(term) >= minFuzzyPrefixLength && len(word) > len(term) && editDistance(term, word[:len(term)]) <= maxEdits {
+		return tokenMatch{score: scoreFuzzyPart, from: 0, to: len(term)}
+	}
+	if editDistance(term, word) <= maxEdits {
+		return tokenMatch{score: scoreFuzzy, from: 0, to: len(word)}
+	}
+	return tokenMatch{}
+}
+
+// allowedEdits số lỗi chính tả cho phép theo độ dài term, giống fuzziness AUTO của Elasticsearch
+func allowedEdits(length int) int {
+	switch {
+	case length <= 2:
+		return 0
+	case length <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// highlight bọc các đoạn match trong value bằng <em></em>
+func highlight(terms [][]rune, value string) (string, bool) {
+	var b strings.Builder
+	last := 0
+	matched := false
+	for _, t := range tokenize(value) {
+		best := tokenMatch{}
+		for _, term := range terms {
+			if tm := matchToken(term, t.text); tm.score > best.score {
+				best = tm
+			}
+		}
+		if best.score == 0 {
+			continue
+		}
+		from := t.runes[best.from]
+		to := t.end
+		if best.to < len(t.runes) {
+			to = t.runes[best.to]
+		}
+		b.WriteString(value[last:from])
+		b.WriteString("<em>")
+		b.WriteString(value[from:to])
+		b.WriteString("</em>")
+		last = to
+		matched = true
+	}
+	if !matched {
+		return "", false
+	}
+	b.WriteString(value[last:])
+	return b.String(), true
+}
+
+func tokenize(s string) []token {
+	var tokens []token
+	var cur *token
+	for i, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if cur == nil {
+				cur = &token{}
+			}
+			cur.text = append(cur.text, foldRune(r))
+			cur.runes = append(cur.runes, i)
+			cur.end = i + len(string(r))
+			continue
+		}
+		if cur != nil {
+			tokens = append(tokens, *cur)
+			cur = nil
+		}
+	}
+	if cur != nil {
+		tokens = append(tokens, *cur)
+	}
+	return tokens
+}
+
+// foldRune lowercase và bỏ dấu tiếng Việt (ví dụ "Đ" -> "d", "ể" -> "e")
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if r == 'đ' {
+		return 'd'
+	}
+	for _, base := range norm.NFD.String(string(r)) {
+		return base
+	}
+	return r
+}
+
+func runeIndex(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if string(s[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// editDistance khoảng cách Damerau-Levenshtein (optimal string alignment), đổi chỗ 2 ký tự liền nhau tính là 1 lỗi
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"services-management/internal/sv_management/repository"
+	"sort"
+)
+
+// Số service tối đa load lên để chấm điểm fuzzy khi text index không đủ kết quả
+const maxFuzzyCandidates = 1000
+
+type mongoEngine struct {
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+}
+
+// NewMongoEngine search bằng Mongo text index, bổ sung fuzzy matching in-process để chịu lỗi chính tả
+func NewMongoEngine(serviceRepo repository.ServiceRepository, serviceGroupRepo repository.ServiceGroupRepository) Engine {
+	return &mongoEngine{
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+	}
+}
+
+func (e *mongoEngine) Search(ctx context.Context, query Query) ([]Hit, error) {
+	filter := repository.ServiceFilter{
+		OrganizationID: query.OrganizationID,
+		Status:         query.Status,
+		Roles:          query.Roles,
+	}
+
+	textHits, err := e.serviceRepo.TextSearch(ctx, query.Text, filter, query.Size)
+	if err != nil {
+		return nil, err
+	}
+	candidates := textHits
+
+	// Services thuộc group có title khớp
+	matchedGroups, err := e.serviceGroupRepo.TextSearch(ctx, query.Text, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchedGroups) > 0 {
+		groupFilter := filter
+		for _, g := range matchedGroups {
+			groupFilter.GroupIDs = append(groupFilter.GroupIDs, g.ID.Hex())
+		}
+		inGroups, _, err := e.serviceRepo.Find(ctx, groupFilter, repository.ListOptions{Size: maxFuzzyCandidates})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, inGroups...)
+	}
+
+	// Text index không chịu lỗi chính tả nên fallback sang fuzzy
+	if len(textHits) < query.Size {
+		all, _, err := e.serviceRepo.Find(ctx, filter, repository.ListOptions{Size: maxFuzzyCandidates})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, all...)
+	}
+
+	groups, err := e.serviceGroupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groupTitles := make(map[string]string, len(groups))
+	for _, g := range groups {
+		groupTitles[g.ID.Hex()] = g.Title
+	}
+
+	textRank := make(map[string]int, len(textHits))
+	for i, svc := range textHits {
+		textRank[svc.ID.Hex()] = i
+	}
+
+	m := newMatcher(query.Text)
+	seen := make(map[string]struct{}, len(candidates))
+	var hits []Hit
+	for _, svc := range candidates {
+		id := svc.ID.Hex()
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		score, highlights := m.match(NewDocument(svc, groupTitles[svc.GroupID]))
+		if rank, ok := textRank[id]; ok {
+			// Ưu tiên kết quả của text index theo thứ hạng
+			score += 1 / float64(rank+1)
+		}
+		if score == 0 {
+			continue
+		}
+		hits = append(hits, Hit{ID: id, Score: score, Highlights: highlights})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	if query.Size > 0 && len(hits) > query.Size {
+		hits = hits[:query.Size]
+	}
+	return hits, nil
+}
+
+// Mongo là nguồn dữ liệu chính nên không cần index riêng
+func (e *mongoEngine) Index(ctx context.Context, doc Document) error {
+	return nil
+}
+
+func (e *mongoEngine) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (e *mongoEngine) Reindex(ctx context.Context, docs []Document) error {
+	return nil
+}
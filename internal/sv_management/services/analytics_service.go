@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"sort"
+	"time"
+)
+
+const (
+	dayLayout         = "2006-01-02"
+	defaultReportDays = 30
+	defaultTopLimit   = 10
+)
+
+type AnalyticsService interface {
+	TopServices(ctx context.Context, req request.UsageReportRequest) (*response.ServiceUsageReportResponse, error)
+	DailyActiveUsers(ctx context.Context, req request.DailyActiveUsersRequest) (*response.DailyUsageReportResponse, error)
+	NeverUsedServices(ctx context.Context, req request.UsageReportRequest) ([]*response.ServiceResDto, error)
+	Trends(ctx context.Context, req request.UsageReportRequest) (*response.ServiceTrendReportResponse, error)
+	RunRollup(ctx context.Context) error
+}
+
+// rollupRunner job rollup usage (tracking.RollupJob)
+type rollupRunner interface {
+	Run(ctx context.Context) error
+}
+
+type analyticsService struct {
+	rollupRepo  repository.UsageRollupRepository
+	serviceRepo repository.ServiceRepository
+	rollup      rollupRunner
+}
+
+func NewAnalyticsService(rollupRepo repository.UsageRollupRepository, serviceRepo repository.ServiceRepository, rollup rollupRunner) AnalyticsService {
+	return &analyticsService{
+		rollupRepo:  rollupRepo,
+		serviceRepo: serviceRepo,
+		rollup:      rollup,
+	}
+}
+
+func (s *analyticsService) TopServices(ctx context.Context, req request.UsageReportRequest) (*response.ServiceUsageReportResponse, error) {
+	from, to, err := reportRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTopLimit
+	}
+	usages, err := s.rollupRepo.UsageByService(ctx, usageFilter(req, from, to), limit)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := s.serviceTitles(ctx, usageServiceIDs(usages))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*response.ServiceUsageResDto, 0, len(usages))
+	for _, u := range usages {
+		items = append(items, &response.ServiceUsageResDto{
+			ServiceID:   u.ServiceID,
+			Title:       titles[u.ServiceID],
+			Clicks:      u.Clicks,
+			UniqueUsers: u.UniqueUsers,
+		})
+	}
+	return &response.ServiceUsageReportResponse{From: from, To: to, Items: items}, nil
+}
+
+func (s *analyticsService) DailyActiveUsers(ctx context.Context, req request.DailyActiveUsersRequest) (*response.DailyUsageReportResponse, error) {
+	from, to, err := reportRange(req.UsageReportRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := usageFilter(req.UsageReportRequest, from, to)
+	filter.ServiceID = req.ServiceID
+	days, err := s.rollupRepo.DailyUsage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*response.DailyUsageResDto, 0, len(days))
+	for _, d := range days {
+		items = append(items, &response.DailyUsageResDto{
+			Day:         d.Day,
+			Clicks:      d.Clicks,
+			ActiveUsers: d.ActiveUsers,
+		})
+	}
+	return &response.DailyUsageReportResponse{ServiceID: req.ServiceID, From: from, To: to, Items: items}, nil
+}
+
+// NeverUsedServices service chưa từng được mở, chỉ giới hạn theo ngày khi truyền from/to
+func (s *analyticsService) NeverUsedServices(ctx context.Context, req request.UsageReportRequest) ([]*response.ServiceResDto, error) {
+	if req.From != "" && req.To != "" && req.From > req.To {
+		return nil, ErrInvalidDateRange
+	}
+
+	usedIDs, err := s.rollupRepo.UsedServiceIDs(ctx, usageFilter(req, req.From, req.To))
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.ServiceFilter{OrganizationID: req.OrganizationID}
+	if req.Role != "" {
+		filter.Roles = []string{req.Role}
+	}
+	services, _, err := s.serviceRepo.Find(ctx, filter, repository.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	used := toSet(usedIDs)
+	unused := make([]*model.Service, 0)
+	for _, svc := range services {
+		if _, ok := used[svc.ID.Hex()]; !ok {
+			unused = append(unused, svc)
+		}
+	}
+	return mapper.MapServicesToServiceResDtos(unused), nil
+}
+
+// Trends so sánh click với kỳ liền trước có cùng độ dài, service tăng nhiều nhất đứng đầu
+func (s *analyticsService) Trends(ctx context.Context, req request.UsageReportRequest) (*response.ServiceTrendReportResponse, error) {
+	from, to, err := reportRange(req)
+	if err != nil {
+		return nil, err
+	}
+	prevFrom, prevTo := previousRange(from, to)
+
+	current, err := s.rollupRepo.UsageByService(ctx, usageFilter(req, from, to), 0)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.rollupRepo.UsageByService(ctx, usageFilter(req, prevFrom, prevTo), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	trends := map[string]*response.ServiceTrendResDto{}
+	trend := func(id string) *response.ServiceTrendResDto {
+		if _, ok := trends[id]; !ok {
+			trends[id] = &response.ServiceTrendResDto{ServiceID: id}
+		}
+		return trends[id]
+	}
+	for _, u := range current {
+		trend(u.ServiceID).Clicks = u.Clicks
+	}
+	for _, u := range previous {
+		trend(u.ServiceID).PreviousClicks = u.Clicks
+	}
+
+	ids := make([]string, 0, len(trends))
+	for id := range trends {
+		ids = append(ids, id)
+	}
+	titles, err := s.serviceTitles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*response.ServiceTrendResDto, 0, len(trends))
+	for _, t := range trends {
+		t.Title = titles[t.ServiceID]
+		t.Change = t.Clicks - t.PreviousClicks
+		if t.PreviousClicks > 0 {
+			pct := float64(t.Change) * 100 / float64(t.PreviousClicks)
+			t.ChangePercent = &pct
+		}
+		items = append(items, t)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Change != items[j].Change {
+			return items[i].Change > items[j].Change
+		}
+		return items[i].ServiceID < items[j].ServiceID
+	})
+	if req.Limit > 0 && len(items) > req.Limit {
+		items = items[:req.Limit]
+	}
+
+	return &response.ServiceTrendReportResponse{
+		From:         from,
+		To:           to,
+		PreviousFrom: prevFrom,
+		PreviousTo:   prevTo,
+		Items:        items,
+	}, nil
+}
+
+func (s *analyticsService) RunRollup(ctx context.Context) error {
+	return s.rollup.Run(ctx)
+}
+
+func (s *analyticsService) serviceTitles(ctx context.Context, ids []string) (map[string]string, error) {
+	services, err := s.serviceRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[string]string, len(services))
+	for _, svc := range services {
+		titles[svc.ID.Hex()] = svc.Title
+	}
+	return titles, nil
+}
+
+// reportRange mặc định tới hôm nay (UTC) và lùi defaultReportDays ngày
+func reportRange(req request.UsageReportRequest) (string, string, error) {
+	to := req.To
+	if to == "" {
+		to = time.Now().UTC().Format(dayLayout)
+	}
+	from := req.From
+	if from == "" {
+		toDay, _ := time.Parse(dayLayout, to)
+		from = toDay.AddDate(0, 0, -(defaultReportDays - 1)).Format(dayLayout)
+	}
+	// yyyy-mm-dd so sánh chuỗi cũng là so sánh ngày
+	if from > to {
+		return "", "", ErrInvalidDateRange
+	}
+	return from, to, nil
+}
+
+func previousRange(from, to string) (string, string) {
+	fromDay, _ := time.Parse(dayLayout, from)
+	toDay, _ := time.Parse(dayLayout, to)
+	days := int(toDay.Sub(fromDay).Hours()/24) + 1
+	return fromDay.AddDate(0, 0, -days).Format(dayLayout), fromDay.AddDate(0, 0, -1).Format(dayLayout)
+}
+
+func usageFilter(req request.UsageReportRequest, from, to string) repository.UsageFilter {
+	return repository.UsageFilter{
+		From:           from,
+		To:             to,
+		OrganizationID: req.OrganizationID,
+		Role:           req.Role,
+	}
+}
+
+func usageServiceIDs(usages []*model.ServiceUsage) []string {
+	ids := make([]string, 0, len(usages))
+	for _, u := range usages {
+		ids = append(ids, u.ServiceID)
+	}
+	return ids
+}
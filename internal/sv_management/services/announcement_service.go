@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// announcementLookahead catalog hiển thị cả lịch bảo trì sắp tới trong khoảng này
+const announcementLookahead = 7 * 24 * time.Hour
+
+type AnnouncementService interface {
+	Create(ctx context.Context, req request.AnnouncementRequest) (*response.AnnouncementResDto, error)
+	Update(ctx context.Context, id string, req request.AnnouncementRequest) (*response.AnnouncementResDto, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, req request.ListAnnouncementsRequest) ([]*response.AnnouncementResDto, error)
+}
+
+type announcementService struct {
+	announcementRepo repository.AnnouncementRepository
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	recorder         events.Recorder
+}
+
+func NewAnnouncementService(
+	announcementRepo repository.AnnouncementRepository,
+	serviceRepo repository.ServiceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	recorder events.Recorder,
+) AnnouncementService {
+	return &announcementService{
+		announcementRepo: announcementRepo,
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		recorder:         recorder,
+	}
+}
+
+func (s *announcementService) Create(ctx context.Context, req request.AnnouncementRequest) (*response.AnnouncementResDto, error) {
+	announcement := &model.Announcement{
+		ID:        primitive.NewObjectID(),
+		CreatedBy: userIDFromContext(ctx),
+	}
+	if err := s.apply(ctx, announcement, req); err != nil {
+		return nil, err
+	}
+	err := s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.announcementRepo.Create(ctx, announcement); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.AnnouncementEvent(events.AnnouncementCreated, announcement)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return announcementResDto(announcement, time.Now()), nil
+}
+
+func (s *announcementService) Update(ctx context.Context, id string, req request.AnnouncementRequest) (*response.AnnouncementResDto, error) {
+	announcement, err := s.announcementRepo.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, announcement, req); err != nil {
+		return nil, err
+	}
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.announcementRepo.Update(ctx, announcement); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.AnnouncementEvent(events.AnnouncementUpdated, announcement)}, nil
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return announcementResDto(announcement, time.Now()), nil
+}
+
+func (s *announcementService) Delete(ctx context.Context, id string) error {
+	announcement, err := s.announcementRepo.GetByID(ctx, id)
+	if err == nil {
+		err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+			if err := s.announcementRepo.Delete(ctx, id); err != nil {
+				return nil, err
+			}
+			return []events.Event{events.AnnouncementEvent(events.AnnouncementDeleted, announcement)}, nil
+		})
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrAnnouncementNotFound
+	}
+	return err
+}
+
+func (s *announcementService) List(ctx context.Context, req request.ListAnnouncementsRequest) ([]*response.AnnouncementResDto, error) {
+	now := time.Now()
+	filter := repository.AnnouncementFilter{
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Kind:       req.Kind,
+	}
+	if req.Current {
+		until := now.Add(announcementLookahead)
+		filter.EndsAfter, filter.StartsBefore = &now, &until
+	}
+
+	announcements, err := s.announcementRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*response.AnnouncementResDto, 0, len(announcements))
+	for _, a := range announcements {
+		result = append(result, announcementResDto(a, now))
+	}
+	return result, nil
+}
+
+// apply kiểm tra request rồi ghi vào announcement
+func (s *announcementService) apply(ctx context.Context, announcement *model.Announcement, req request.AnnouncementRequest) error {
+	organizationID := ""
+	switch req.TargetType {
+	case model.AnnouncementTargetService:
+		svc, err := s.serviceRepo.GetByID(ctx, req.TargetID)
+		if err != nil {
+			return mapServiceNotFound(err)
+		}
+		organizationID = svc.OrganizationID
+	case model.AnnouncementTargetGroup:
+		group, err := s.serviceGroupRepo.GetByID(ctx, req.TargetID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+				return ErrServiceGroupNotFound
+			}
+			return err
+		}
+		organizationID = group.OrganizationID
+	}
+
+	startsAt := time.Now()
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(startsAt) {
+		return ErrInvalidAnnouncementWindow
+	}
+	if req.Kind == model.AnnouncementKindMaintenance && req.EndsAt == nil {
+		return ErrMaintenanceEndRequired
+	}
+
+	severity := req.Severity
+	if severity == "" {
+		severity = "info"
+		if req.Kind == model.AnnouncementKindMaintenance {
+			severity = "warning"
+		}
+	}
+
+	announcement.TargetType = req.TargetType
+	announcement.TargetID = req.TargetID
+	announcement.OrganizationID = organizationID
+	announcement.Kind = req.Kind
+	announcement.Severity = severity
+	announcement.Message = req.Message
+	announcement.StartsAt = startsAt
+	announcement.EndsAt = req.EndsAt
+	announcement.DisableLaunch = req.DisableLaunch
+	return nil
+}
+
+func announcementResDto(a *model.Announcement, now time.Time) *response.AnnouncementResDto {
+	return &response.AnnouncementResDto{
+		ID:            a.ID.Hex(),
+		TargetType:    a.TargetType,
+		TargetID:      a.TargetID,
+		Kind:          a.Kind,
+		Severity:      a.Severity,
+		Message:       a.Message,
+		StartsAt:      a.StartsAt,
+		EndsAt:        a.EndsAt,
+		DisableLaunch: a.DisableLaunch,
+		Active:        a.ActiveAt(now),
+	}
+}
+
+// announcementIndex announcement đang hoặc sắp hiệu lực, tra theo service và group
+type announcementIndex struct {
+	now       time.Time
+	byService map[string][]*model.Announcement
+	byGroup   map[string][]*model.Announcement
+}
+
+func loadAnnouncements(ctx context.Context, repo repository.AnnouncementRepository) (*announcementIndex, error) {
+	now := time.Now()
+	until := now.Add(announcementLookahead)
+	announcements, err := repo.Find(ctx, repository.AnnouncementFilter{EndsAfter: &now, StartsBefore: &until})
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &announcementIndex{
+		now:       now,
+		byService: map[string][]*model.Announcement{},
+		byGroup:   map[string][]*model.Announcement{},
+	}
+	for _, a := range announcements {
+		if a.TargetType == model.AnnouncementTargetGroup {
+			idx.byGroup[a.TargetID] = append(idx.byGroup[a.TargetID], a)
+		} else {
+			idx.byService[a.TargetID] = append(idx.byService[a.TargetID], a)
+		}
+	}
+	return idx, nil
+}
+
+func (idx *announcementIndex) resDtos(announcements []*model.Announcement) []response.AnnouncementResDto {
+	if len(announcements) == 0 {
+		return nil
+	}
+	result := make([]response.AnnouncementResDto, 0, len(announcements))
+	for _, a := range announcements {
+		result = append(result, *announcementResDto(a, idx.now))
+	}
+	return result
+}
+
+// blocking lịch bảo trì đang chặn launch service, xét cả group chứa service và các group tổ tiên
+func (idx *announcementIndex) blocking(serviceID string, groupIDs []string) *model.Announcement {
+	candidates := append([]*model.Announcement{}, idx.byService[serviceID]...)
+	for _, id := range groupIDs {
+		candidates = append(candidates, idx.byGroup[id]...)
+	}
+	for _, a := range candidates {
+		if a.DisableLaunch && a.ActiveAt(idx.now) {
+			return a
+		}
+	}
+	return nil
+}
+
+// applyToCatalog gắn announcement vào group/service của catalog và đánh dấu service không launch được
+func (idx *announcementIndex) applyToCatalog(catalog []*response.ServicesResponse, serviceGroups map[string]string, groups map[string]*model.ServiceGroup) {
+	for _, item := range catalog {
+		item.Group.Announcements = idx.resDtos(idx.byGroup[item.Group.ID])
+		for i := range item.Services {
+			idx.applyToService(&item.Services[i], serviceGroups, groups)
+		}
+	}
+}
+
+func (idx *announcementIndex) applyToService(svc *response.ServiceResDto, serviceGroups map[string]string, groups map[string]*model.ServiceGroup) {
+	svc.Announcements = idx.resDtos(idx.byService[svc.ID])
+	svc.LaunchDisabled = idx.blocking(svc.ID, groupChain(serviceGroups[svc.ID], groups)) != nil
+}
+
+// groupChain group của service và các group tổ tiên
+func groupChain(groupID string, groups map[string]*model.ServiceGroup) []string {
+	if groupID == "" {
+		return nil
+	}
+	chain := []string{groupID}
+	if g, ok := groups[groupID]; ok {
+		chain = append(chain, g.Path...)
+	}
+	return chain
+}
+
+func maintenanceError(a *model.Announcement) error {
+	return &MaintenanceError{Until: a.EndsAt, Message: a.Message}
+}
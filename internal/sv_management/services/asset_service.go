@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/internal/sv_management/storage"
+	"services-management/pkg/imaging"
+	"strings"
+
+	_ "image/gif"
+	_ "image/jpeg"
+
+	_ "golang.org/x/image/webp"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Kích thước icon chuẩn của launcher
+var launcherIconSizes = []int{32, 64, 128, 256}
+
+// maxAssetDimension chiều rộng/cao tối đa của ảnh upload, chặn ảnh nhỏ về byte nhưng khai báo
+// kích thước rất lớn (decompression bomb) trước khi decode cả ảnh vào bộ nhớ
+const maxAssetDimension = 4096
+
+var allowedAssetTypes = map[string]string{
+	"image/png":     ".png",
+	"image/jpeg":    ".jpg",
+	"image/gif":     ".gif",
+	"image/webp":    ".webp",
+	"image/svg+xml": ".svg",
+}
+
+// AssetContent nội dung file để handler stream về client
+type AssetContent struct {
+	Reader      io.ReadCloser
+	ContentType string
+	Size        int64
+	ETag        string
+}
+
+type AssetService interface {
+	Upload(ctx context.Context, file *multipart.FileHeader, organizationID string) (*response.AssetResDto, error)
+	Open(ctx context.Context, id string, size int) (*AssetContent, error)
+	Delete(ctx context.Context, id string) error
+	ResolveIcon(ctx context.Context, req *request.IconRequest) (*model.Icon, error)
+}
+
+type assetService struct {
+	repository       repository.AssetRepository
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	storage          storage.Storage
+	maxSize          int64
+	publicUrl        string
+}
+
+func NewAssetService(repository repository.AssetRepository, serviceRepo repository.ServiceRepository, serviceGroupRepo repository.ServiceGroupRepository, storage storage.Storage, maxSize int64, publicUrl string) AssetService {
+	return &assetService{
+		repository:       repository,
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		storage:          storage,
+		maxSize:          maxSize,
+		publicUrl:        strings.TrimRight(publicUrl, "/"),
+	}
+}
+
+func (s *assetService) Upload(ctx context.Context, file *multipart.FileHeader, organizationID string) (*response.AssetResDto, error) {
+	if file.Size > s.maxSize {
+		return nil, ErrAssetTooLarge
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, ErrAssetTooLarge
+	}
+
+	// Không tin Content-Type client gửi lên, tự nhận diện từ nội dung
+	contentType := detectContentType(data)
+	ext, ok := allowedAssetTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidAsset
+	}
+
+	id := primitive.NewObjectID()
+	asset := &model.Asset{
+		ID:             id,
+		OrganizationID: organizationID,
+		FileName:       filepath.Base(file.Filename),
+		ContentType:    contentType,
+		Size:           int64(len(data)),
+		StorageKey:     fmt.Sprintf("%s/original%s", id.Hex(), ext),
+		CreatedBy:      userIDFromContext(ctx),
+	}
+
+	// SVG là ảnh vector nên không cần resize
+	if contentType != "image/svg+xml" {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrInvalidAsset
+		}
+		if cfg.Width > maxAssetDimension || cfg.Height > maxAssetDimension {
+			return nil, ErrAssetTooLargeDimensions
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrInvalidAsset
+		}
+		asset.Width = img.Bounds().Dx()
+		asset.Height = img.Bounds().Dy()
+
+		variants, err := s.storeVariants(ctx, id.Hex(), img)
+		if err != nil {
+			return nil, err
+		}
+		asset.Variants = variants
+	}
+
+	if err := s.storage.Put(ctx, asset.StorageKey, bytes.NewReader(data), contentType); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Create(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	return mapper.MapAssetToAssetResDto(asset, s.publicUrl), nil
+}
+
+// storeVariants resize ảnh về các kích thước chuẩn nhỏ hơn ảnh gốc
+func (s *assetService) storeVariants(ctx context.Context, id string, img image.Image) ([]model.AssetVariant, error) {
+	longest := max(img.Bounds().Dx(), img.Bounds().Dy())
+
+	var variants []model.AssetVariant
+	for _, size := range launcherIconSizes {
+		if size >= longest {
+			break
+		}
+
+		resized := imaging.Fit(img, size)
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, resized); err != nil {
+			return nil, err
+		}
+
+		variant := model.AssetVariant{
+			Size:        size,
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			ContentType: "image/png",
+			ByteSize:    int64(buf.Len()),
+			StorageKey:  fmt.Sprintf("%s/%d.png", id, size),
+		}
+		if err := s.storage.Put(ctx, variant.StorageKey, &buf, variant.ContentType); err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// Open trả về variant nhỏ nhất không nhỏ hơn size, size = 0 hoặc lớn hơn mọi variant thì trả ảnh gốc
+func (s *assetService) Open(ctx context.Context, id string, size int) (*AssetContent, error) {
+	asset, err := s.getAsset(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key, contentType, byteSize, variantSize := asset.StorageKey, asset.ContentType, asset.Size, 0
+	if size > 0 {
+		for _, v := range asset.Variants {
+			if v.Size >= size {
+				key, contentType, byteSize, variantSize = v.StorageKey, v.ContentType, v.ByteSize, v.Size
+				break
+			}
+		}
+	}
+
+	reader, err := s.storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrAssetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &AssetContent{
+		Reader:      reader,
+		ContentType: contentType,
+		Size:        byteSize,
+		ETag:        fmt.Sprintf(`"%s-%d"`, asset.ID.Hex(), variantSize),
+	}, nil
+}
+
+// Delete asset còn là icon của service hoặc group thì trả về ErrAssetInUse để catalog không có icon hỏng
+func (s *assetService) Delete(ctx context.Context, id string) error {
+	asset, err := s.getAsset(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.serviceRepo.UsesIcon(ctx, id)
+	if err == nil && !inUse {
+		inUse, err = s.serviceGroupRepo.UsesIcon(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrAssetInUse
+	}
+
+	for _, v := range asset.Variants {
+		if err := s.storage.Delete(ctx, v.StorageKey); err != nil {
+			return err
+		}
+	}
+	if err := s.storage.Delete(ctx, asset.StorageKey); err != nil {
+		return err
+	}
+	return s.repository.Delete(ctx, id)
+}
+
+// ResolveIcon kiểm tra asset được tham chiếu có tồn tại và điền URL serve của asset
+func (s *assetService) ResolveIcon(ctx context.Context, req *request.IconRequest) (*model.Icon, error) {
+	icon := mapper.MapIconRequestToIcon(req)
+	if icon == nil || icon.ImageKey == "" {
+		return icon, nil
+	}
+
+	if _, err := s.getAsset(ctx, icon.ImageKey); err != nil {
+		return nil, err
+	}
+	icon.ImageUrl = s.publicUrl + "/" + icon.ImageKey
+	return icon, nil
+}
+
+func (s *assetService) getAsset(ctx context.Context, id string) (*model.Asset, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrAssetNotFound
+	}
+	asset, err := s.repository.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrAssetNotFound
+	}
+	return asset, err
+}
+
+func detectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	// DetectContentType không nhận diện SVG
+	if (contentType == "text/xml" || contentType == "text/plain") && bytes.Contains(bytes.ToLower(data), []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return contentType
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/gateway"
+	"services-management/internal/sv_management/events"
+	"services-management/pkg/constants"
+)
+
+type CatalogStreamService interface {
+	// Subscribe mở stream event catalog user được thấy trong organization đang dùng của user,
+	// stream đóng khi ctx bị huỷ
+	Subscribe(ctx context.Context, lastEventID string) (*CatalogSubscription, error)
+}
+
+// CatalogSubscription Missed là các event sau lastEventID đã lọc theo quyền.
+// Resumed=false khi không còn lastEventID trong history, client cần tải lại catalog
+type CatalogSubscription struct {
+	Events  <-chan events.Event
+	Missed  []events.Event
+	Resumed bool
+}
+
+type catalogStreamService struct {
+	bus         *events.Bus
+	userGateway gateway.UserGateway
+}
+
+func NewCatalogStreamService(bus *events.Bus, userGateway gateway.UserGateway) CatalogStreamService {
+	return &catalogStreamService{
+		bus:         bus,
+		userGateway: userGateway,
+	}
+}
+
+func (s *catalogStreamService) Subscribe(ctx context.Context, lastEventID string) (*CatalogSubscription, error) {
+	user, err := currentUser(ctx, s.userGateway)
+	if err != nil {
+		return nil, err
+	}
+	organizationID := user.OrganizationIdActive
+	roles := rolesFromContext(ctx)
+	stream, missed, resumed := s.bus.Subscribe(lastEventID)
+
+	out := make(chan events.Event)
+	go func() {
+		defer close(out)
+		defer stream.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-stream.Events():
+				if !ok {
+					return
+				}
+				if !eventVisible(event, organizationID, roles) {
+					continue
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	visibleMissed := make([]events.Event, 0, len(missed))
+	for _, event := range missed {
+		if eventVisible(event, organizationID, roles) {
+			visibleMissed = append(visibleMissed, event)
+		}
+	}
+	return &CatalogSubscription{
+		Events:  out,
+		Missed:  visibleMissed,
+		Resumed: resumed,
+	}, nil
+}
+
+// eventVisible cùng điều kiện organization/role với catalog. Không lọc theo status
+// để client còn nhận được event service bị tắt và tự bỏ khỏi danh sách
+func eventVisible(event events.Event, organizationID string, roles []string) bool {
+	switch data := event.Data.(type) {
+	case events.ServiceData:
+		return sameOrganization(data.OrganizationID, organizationID) && hasAnyRole(data.Roles, roles)
+	case events.GroupData:
+		return sameOrganization(data.OrganizationID, organizationID)
+	case events.ReorderData:
+		return sameOrganization(data.OrganizationID, organizationID)
+	case events.AnnouncementData:
+		return sameOrganization(data.OrganizationID, organizationID)
+	case events.CollectionData:
+		return sameOrganization(data.OrganizationID, organizationID)
+	}
+	return true
+}
+
+// isActive service tạo trước khi có status (status rỗng) được coi là active
+func isActive(status string) bool {
+	return status == "" || status == string(constants.ServiceStatusActive)
+}
+
+// sameOrganization entity không gắn organization là dùng chung, còn lại phải đúng organization của user.
+// User chưa có organization (organizationID rỗng) chỉ thấy entity dùng chung
+func sameOrganization(owner, organizationID string) bool {
+	return owner == "" || owner == organizationID
+}
+
+// hasAnyRole service không giới hạn role thì ai cũng thấy
+func hasAnyRole(allowed, roles []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, role := range allowed {
+		for _, userRole := range roles {
+			if role == userRole {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/gateway"
+	"services-management/internal/sv_management/changefeed"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/pkg/i18n"
+)
+
+const defaultChangesLimit = 500
+
+type CatalogSyncService interface {
+	// Changes thay đổi service/group sau cursor since theo quyền của user hiện tại,
+	// entity user không còn được thấy trả về dạng tombstone
+	Changes(ctx context.Context, req request.CatalogChangesRequest) (*response.CatalogChangesResponse, error)
+}
+
+type catalogSyncService struct {
+	changeRepo       repository.CatalogChangeRepository
+	counterRepo      repository.CounterRepository
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	localeResolver   *i18n.Resolver
+	userGateway      gateway.UserGateway
+}
+
+func NewCatalogSyncService(
+	changeRepo repository.CatalogChangeRepository,
+	counterRepo repository.CounterRepository,
+	serviceRepo repository.ServiceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	localeResolver *i18n.Resolver,
+	userGateway gateway.UserGateway,
+) CatalogSyncService {
+	return &catalogSyncService{
+		changeRepo:       changeRepo,
+		counterRepo:      counterRepo,
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		localeResolver:   localeResolver,
+		userGateway:      userGateway,
+	}
+}
+
+func (s *catalogSyncService) Changes(ctx context.Context, req request.CatalogChangesRequest) (*response.CatalogChangesResponse, error) {
+	user, err := currentUser(ctx, s.userGateway)
+	if err != nil {
+		return nil, err
+	}
+	organizationID := user.OrganizationIdActive
+
+	// cursor lớn hơn seq hiện tại nghĩa là dữ liệu đã bị dựng lại
+	stable, current, err := changefeed.Stable(ctx, s.counterRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.Since > current {
+		return &response.CatalogChangesResponse{Changes: []response.CatalogChangeResDto{}, Reset: true}, nil
+	}
+	// chỉ trả seq tới stable: seq đang ghi dở có thể commit sau seq lớn hơn nó (tắt transaction),
+	// trả seq lớn hơn thì cursor sẽ bỏ qua nó
+	if req.Since >= stable {
+		return &response.CatalogChangesResponse{Changes: []response.CatalogChangeResDto{}, NextCursor: req.Since}, nil
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultChangesLimit
+	}
+	changes, err := s.changeRepo.Since(ctx, repository.CatalogChangeFilter{
+		OrganizationID: organizationID,
+		AfterSeq:       req.Since,
+		UpToSeq:        stable,
+	}, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	services, groups, err := s.loadEntities(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	locales := s.localeResolver.Chain(req.Locales...)
+	roles := rolesFromContext(ctx)
+	values := newURLValues(ctx, s.userGateway, user)
+
+	result := &response.CatalogChangesResponse{
+		Changes:    make([]response.CatalogChangeResDto, 0, len(changes)),
+		NextCursor: req.Since,
+		HasMore:    hasMore,
+	}
+	for _, change := range changes {
+		item := response.CatalogChangeResDto{
+			Type:      change.EntityType,
+			ID:        change.EntityID,
+			Seq:       change.Seq,
+			Deleted:   true,
+			ChangedAt: change.ChangedAt,
+		}
+		switch change.EntityType {
+		case changefeed.EntityService:
+			if svc, ok := services[change.EntityID]; ok && !change.Deleted && serviceVisible(svc, organizationID, roles) {
+				dto := mapper.MapServiceToServiceResDto(*svc, locales...)
+				values.renderService(dto)
+				item.Service = dto
+				item.Deleted = false
+			}
+		case changefeed.EntityGroup:
+			if g, ok := groups[change.EntityID]; ok && !change.Deleted && sameOrganization(g.OrganizationID, organizationID) {
+				dto := mapper.MapServiceGroupResponse(g, locales...)
+				item.Group = &dto
+				item.Deleted = false
+			}
+		}
+		result.Changes = append(result.Changes, item)
+		result.NextCursor = change.Seq
+	}
+	return result, nil
+}
+
+func (s *catalogSyncService) loadEntities(ctx context.Context, changes []*model.CatalogChange) (map[string]*model.Service, map[string]*model.ServiceGroup, error) {
+	var serviceIDs, groupIDs []string
+	for _, change := range changes {
+		if change.Deleted {
+			continue
+		}
+		switch change.EntityType {
+		case changefeed.EntityService:
+			serviceIDs = append(serviceIDs, change.EntityID)
+		case changefeed.EntityGroup:
+			groupIDs = append(groupIDs, change.EntityID)
+		}
+	}
+
+	services := make(map[string]*model.Service, len(serviceIDs))
+	if len(serviceIDs) > 0 {
+		found, err := s.serviceRepo.GetByIDs(ctx, serviceIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, svc := range found {
+			services[svc.ID.Hex()] = svc
+		}
+	}
+
+	groups := make(map[string]*model.ServiceGroup, len(groupIDs))
+	if len(groupIDs) > 0 {
+		found, _, err := s.serviceGroupRepo.Find(ctx, repository.ServiceGroupFilter{IDs: groupIDs}, repository.ListOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, g := range found {
+			groups[g.ID.Hex()] = g
+		}
+	}
+	return services, groups, nil
+}
+
+// serviceVisible cùng điều kiện với catalog của user: active, đúng organization và role
+func serviceVisible(svc *model.Service, organizationID string, roles []string) bool {
+	return isActive(svc.Status) &&
+		sameOrganization(svc.OrganizationID, organizationID) &&
+		hasAnyRole(svc.Roles, roles)
+}
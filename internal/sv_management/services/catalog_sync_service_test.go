@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/gateway"
+	"services-management/internal/gateway/dto"
+	"services-management/internal/sv_management/changefeed"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/pkg/constants"
+	"services-management/pkg/i18n"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryCounters bộ đếm trong bộ nhớ, giữ pending như bản mongo
+type memoryCounters struct {
+	mu      sync.Mutex
+	value   int64
+	pending []int64
+}
+
+func (c *memoryCounters) Next(_ context.Context, _ string, n int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value += n
+	c.pending = append(c.pending, c.value-n+1)
+	return c.value, nil
+}
+
+func (c *memoryCounters) Done(_ context.Context, _ string, first int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = slices.DeleteFunc(c.pending, func(seq int64) bool { return seq == first })
+	return nil
+}
+
+func (c *memoryCounters) Current(ctx context.Context, name string) (int64, error) {
+	counter, err := c.Get(ctx, name)
+	return counter.Value, err
+}
+
+func (c *memoryCounters) Get(_ context.Context, name string) (*model.Counter, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counter := &model.Counter{ID: name, Value: c.value}
+	for _, seq := range c.pending {
+		counter.Pending = append(counter.Pending, model.CounterPending{Seq: seq})
+	}
+	return counter, nil
+}
+
+// memoryChanges bản ghi thay đổi trong bộ nhớ, Upsert cộng dồn organization như bản mongo
+type memoryChanges struct {
+	mu      sync.Mutex
+	changes map[string]model.CatalogChange
+}
+
+func newMemoryChanges() *memoryChanges {
+	return &memoryChanges{changes: make(map[string]model.CatalogChange)}
+}
+
+func (c *memoryChanges) Upsert(_ context.Context, changes []*model.CatalogChange) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, change := range changes {
+		saved := *change
+		saved.OrganizationIDs = c.changes[change.ID].OrganizationIDs
+		if !slices.Contains(saved.OrganizationIDs, change.OrganizationID) {
+			saved.OrganizationIDs = append(slices.Clone(saved.OrganizationIDs), change.OrganizationID)
+		}
+		c.changes[change.ID] = saved
+	}
+	return nil
+}
+
+func (c *memoryChanges) Since(_ context.Context, filter repository.CatalogChangeFilter, limit int) ([]*model.CatalogChange, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var result []*model.CatalogChange
+	for _, change := range c.changes {
+		if change.Seq <= filter.AfterSeq || filter.UpToSeq > 0 && change.Seq > filter.UpToSeq {
+			continue
+		}
+		if !slices.Contains(change.OrganizationIDs, filter.OrganizationID) && !slices.Contains(change.OrganizationIDs, "") {
+			continue
+		}
+		change := change
+		result = append(result, &change)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Seq < result[j].Seq })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (c *memoryChanges) EnsureIndexes(context.Context) error {
+	return nil
+}
+
+// gatedChanges Upsert dừng lại tới khi gate được đóng, giả lập writer đã cấp seq nhưng chưa ghi xong
+type gatedChanges struct {
+	*memoryChanges
+	entered chan struct{}
+	gate    chan struct{}
+}
+
+func (c *gatedChanges) Upsert(ctx context.Context, changes []*model.CatalogChange) error {
+	close(c.entered)
+	<-c.gate
+	return c.memoryChanges.Upsert(ctx, changes)
+}
+
+// memoryServices chỉ cài các method đọc mà test dùng tới
+type memoryServices struct {
+	repository.ServiceRepository
+	services map[string]*model.Service
+}
+
+func (r *memoryServices) GetByID(_ context.Context, id string) (*model.Service, error) {
+	if svc, ok := r.services[id]; ok {
+		return svc, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *memoryServices) GetByIDs(_ context.Context, ids []string) ([]*model.Service, error) {
+	var result []*model.Service
+	for _, id := range ids {
+		if svc, ok := r.services[id]; ok {
+			result = append(result, svc)
+		}
+	}
+	return result, nil
+}
+
+type memoryGroups struct {
+	repository.ServiceGroupRepository
+	groups map[string]*model.ServiceGroup
+}
+
+func (r *memoryGroups) Find(_ context.Context, filter repository.ServiceGroupFilter, _ repository.ListOptions) ([]*model.ServiceGroup, int64, error) {
+	var result []*model.ServiceGroup
+	for _, id := range filter.IDs {
+		if g, ok := r.groups[id]; ok {
+			result = append(result, g)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+// staticUser user service luôn trả về cùng một user
+type staticUser struct {
+	gateway.UserGateway
+	user *dto.CurrentUser
+}
+
+func (g staticUser) GetCurrentUser(context.Context) (*dto.CurrentUser, error) {
+	return g.user, nil
+}
+
+type syncFixture struct {
+	counters *memoryCounters
+	changes  *memoryChanges
+	services *memoryServices
+	sync     CatalogSyncService
+}
+
+func newSyncFixture() *syncFixture {
+	f := &syncFixture{
+		counters: &memoryCounters{},
+		changes:  newMemoryChanges(),
+		services: &memoryServices{services: make(map[string]*model.Service)},
+	}
+	f.sync = NewCatalogSyncService(
+		f.changes,
+		f.counters,
+		f.services,
+		&memoryGroups{groups: make(map[string]*model.ServiceGroup)},
+		i18n.NewResolver("vi", nil, []string{"vi"}),
+		staticUser{user: &dto.CurrentUser{ID: "u1", OrganizationIdActive: "org-1"}},
+	)
+	return f
+}
+
+// track ghi bản ghi thay đổi của service qua changes
+func (f *syncFixture) track(changes repository.CatalogChangeRepository, svc *model.Service, deleted bool) error {
+	return changefeed.Track(context.Background(), changes, f.counters, []*model.CatalogChange{{
+		ID:             changefeed.EntityService + "-" + svc.ID.Hex(),
+		EntityType:     changefeed.EntityService,
+		EntityID:       svc.ID.Hex(),
+		OrganizationID: svc.OrganizationID,
+		Deleted:        deleted,
+	}})
+}
+
+// saveService lưu service và bản ghi thay đổi của nó
+func (f *syncFixture) saveService(t *testing.T, svc *model.Service, deleted bool) {
+	t.Helper()
+	f.services.services[svc.ID.Hex()] = svc
+	if err := f.track(f.changes, svc, deleted); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *syncFixture) changesSince(t *testing.T, since int64) *response.CatalogChangesResponse {
+	t.Helper()
+	ctx := context.WithValue(context.Background(), constants.UserRoles, "student")
+	res, err := f.sync.Changes(ctx, request.CatalogChangesRequest{Since: since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func newService(title string) *model.Service {
+	return &model.Service{
+		ID:             primitive.NewObjectID(),
+		OrganizationID: "org-1",
+		Title:          title,
+		Status:         string(constants.ServiceStatusActive),
+	}
+}
+
+func changedSeqs(res *response.CatalogChangesResponse) []int64 {
+	seqs := make([]int64, 0, len(res.Changes))
+	for _, change := range res.Changes {
+		seqs = append(seqs, change.Seq)
+	}
+	return seqs
+}
+
+func TestChangesWaitsForInFlightSeq(t *testing.T) {
+	f := newSyncFixture()
+	f.saveService(t, newService("Mail"), false)
+
+	// A được cấp seq 2 nhưng chưa ghi xong, B được cấp seq 3 và ghi xong trước
+	gated := &gatedChanges{memoryChanges: f.changes, entered: make(chan struct{}), gate: make(chan struct{})}
+	drive := newService("Drive")
+	f.services.services[drive.ID.Hex()] = drive
+	writerA := make(chan error, 1)
+	go func() {
+		writerA <- f.track(gated, drive, false)
+	}()
+	<-gated.entered
+	f.saveService(t, newService("Chat"), false)
+
+	res := f.changesSince(t, 1)
+	if len(res.Changes) != 0 || res.NextCursor != 1 {
+		t.Fatalf("with seq 2 in flight got seqs %v next_cursor %d, want none at cursor 1", changedSeqs(res), res.NextCursor)
+	}
+
+	close(gated.gate)
+	if err := <-writerA; err != nil {
+		t.Fatal(err)
+	}
+
+	res = f.changesSince(t, res.NextCursor)
+	if got := changedSeqs(res); !slices.Equal(got, []int64{2, 3}) || res.NextCursor != 3 {
+		t.Fatalf("after seq 2 is written got seqs %v next_cursor %d, want [2 3] at cursor 3", got, res.NextCursor)
+	}
+}
+
+func TestChangesTombstones(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(svc *model.Service)
+		deleted bool // bản ghi thay đổi đánh dấu xoá
+		visible bool
+	}{
+		{name: "service còn thấy", update: func(*model.Service) {}, visible: true},
+		{name: "status rỗng coi như active", update: func(svc *model.Service) { svc.Status = "" }, visible: true},
+		{name: "đúng role", update: func(svc *model.Service) { svc.Roles = []string{"teacher", "student"} }, visible: true},
+		{name: "bị xoá", update: func(*model.Service) {}, deleted: true},
+		{name: "ngừng hoạt động", update: func(svc *model.Service) { svc.Status = string(constants.ServiceStatusInactive) }},
+		{name: "không còn khớp role", update: func(svc *model.Service) { svc.Roles = []string{"teacher"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSyncFixture()
+			svc := newService("Mail")
+			f.saveService(t, svc, false)
+
+			tt.update(svc)
+			f.saveService(t, svc, tt.deleted)
+
+			res := f.changesSince(t, 1)
+			if len(res.Changes) != 1 {
+				t.Fatalf("got %d changes, want 1", len(res.Changes))
+			}
+			change := res.Changes[0]
+			if change.ID != svc.ID.Hex() || change.Seq != 2 || res.NextCursor != 2 {
+				t.Fatalf("change %s at seq %d next_cursor %d, want %s at seq 2", change.ID, change.Seq, res.NextCursor, svc.ID.Hex())
+			}
+			if change.Deleted == tt.visible || (change.Service != nil) != tt.visible {
+				t.Fatalf("deleted = %v with service %v, want visible %v", change.Deleted, change.Service, tt.visible)
+			}
+		})
+	}
+}
+
+func TestChangesTombstoneForOldOrganization(t *testing.T) {
+	f := newSyncFixture()
+	svc := newService("Mail")
+	f.saveService(t, svc, false)
+
+	// service chuyển sang organization khác, organization cũ vẫn nhận tombstone
+	svc.OrganizationID = "org-2"
+	f.saveService(t, svc, false)
+
+	res := f.changesSince(t, 0)
+	if len(res.Changes) != 1 || !res.Changes[0].Deleted || res.Changes[0].Seq != 2 {
+		t.Fatalf("changes = %+v, want one tombstone at seq 2", res.Changes)
+	}
+}
+
+func TestChangesCursor(t *testing.T) {
+	f := newSyncFixture()
+	mail, drive := newService("Mail"), newService("Drive")
+	f.saveService(t, mail, false)  // seq 1
+	f.saveService(t, drive, false) // seq 2
+	f.saveService(t, newService("Chat"), false)
+	f.saveService(t, mail, false) // seq 4, thay bản ghi seq 1
+
+	tests := []struct {
+		name    string
+		since   int64
+		limit   int
+		seqs    []int64
+		cursor  int64
+		hasMore bool
+		reset   bool
+	}{
+		{name: "sync toàn bộ, mỗi entity một lần", since: 0, seqs: []int64{2, 3, 4}, cursor: 4},
+		{name: "phân trang", since: 0, limit: 2, seqs: []int64{2, 3}, cursor: 3, hasMore: true},
+		{name: "trang cuối", since: 3, limit: 2, seqs: []int64{4}, cursor: 4},
+		{name: "chỉ thay đổi sau since", since: 2, seqs: []int64{3, 4}, cursor: 4},
+		{name: "không có thay đổi giữ nguyên cursor", since: 4, seqs: []int64{}, cursor: 4},
+		{name: "cursor vượt seq hiện tại thì reset", since: 9, seqs: []int64{}, reset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), constants.UserRoles, "student")
+			res, err := f.sync.Changes(ctx, request.CatalogChangesRequest{Since: tt.since, Limit: tt.limit})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := changedSeqs(res); !slices.Equal(got, tt.seqs) {
+				t.Fatalf("seqs = %v, want %v", got, tt.seqs)
+			}
+			if res.NextCursor != tt.cursor || res.HasMore != tt.hasMore || res.Reset != tt.reset {
+				t.Fatalf("next_cursor %d has_more %v reset %v, want %d %v %v",
+					res.NextCursor, res.HasMore, res.Reset, tt.cursor, tt.hasMore, tt.reset)
+			}
+		})
+	}
+}
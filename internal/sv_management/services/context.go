@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"services-management/internal/gateway"
+	"services-management/internal/gateway/dto"
+	"services-management/pkg/constants"
+	"strings"
+)
+
+// userIDFromContext user_id do middleware Secured gắn vào request context
+func userIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(constants.UserID).(string)
+	return userID
+}
+
+// rolesFromContext roles trong JWT dạng "SuperAdmin, Teacher"
+func rolesFromContext(ctx context.Context) []string {
+	rolesStr, _ := ctx.Value(constants.UserRoles).(string)
+
+	roles := []string{}
+	for _, role := range strings.Split(rolesStr, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// currentUser user hiện tại theo user service. Organization của user (OrganizationIdActive)
+// chỉ lấy từ đây, không nhận từ query vì client tự điền được organization khác
+func currentUser(ctx context.Context, userGateway gateway.UserGateway) (*dto.CurrentUser, error) {
+	user, err := userGateway.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"services-management/internal/sv_management/dependency"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"strings"
+	"time"
+)
+
+type DependencyService interface {
+	// SetDependencies thay toàn bộ danh sách service mà serviceID phụ thuộc, không cho tạo vòng
+	SetDependencies(ctx context.Context, serviceID string, req request.SetDependenciesRequest) (*response.DependenciesResDto, error)
+	Graph(ctx context.Context, req request.DependencyGraphRequest) (*response.DependencyGraphResDto, error)
+	// GraphDOT đồ thị dạng Graphviz DOT
+	GraphDOT(ctx context.Context, req request.DependencyGraphRequest) (string, error)
+	// Impact các service bị ảnh hưởng nếu serviceID down
+	Impact(ctx context.Context, serviceID string) (*response.ImpactResDto, error)
+	// ReportStatusChange được gọi khi trạng thái health của service đổi,
+	// service chuyển sang down thì ghi log các service bị ảnh hưởng
+	ReportStatusChange(ctx context.Context, svc *model.Service, state model.HealthState)
+}
+
+// dependencyLease kiểm tra vòng và ghi dependency phải tuần tự giữa các instance,
+// không thì hai admin đặt A->B và B->A cùng lúc đều qua được kiểm tra
+const dependencyLease = "service-dependencies"
+
+type dependencyService struct {
+	serviceRepo repository.ServiceRepository
+	leaseRepo   repository.LeaseRepository
+	recorder    events.Recorder
+}
+
+func NewDependencyService(serviceRepo repository.ServiceRepository, leaseRepo repository.LeaseRepository, recorder events.Recorder) DependencyService {
+	return &dependencyService{
+		serviceRepo: serviceRepo,
+		leaseRepo:   leaseRepo,
+		recorder:    recorder,
+	}
+}
+
+func (s *dependencyService) SetDependencies(ctx context.Context, serviceID string, req request.SetDependenciesRequest) (*response.DependenciesResDto, error) {
+	unlock, err := holdLease(ctx, s.leaseRepo, dependencyLease, ErrDependencyLocked)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	graph, err := loadDependencyGraph(ctx, s.serviceRepo, "")
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := graph.Service(serviceID)
+	if !ok {
+		return nil, ErrServiceNotFound
+	}
+
+	dependsOn := uniqueValues(req.DependsOn)
+	for _, id := range dependsOn {
+		if _, ok := graph.Service(id); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrDependencyNotFound, id)
+		}
+	}
+	if cycle := graph.Cycle(serviceID, dependsOn); cycle != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(serviceTitles(graph, cycle), " -> "))
+	}
+
+	svc.DependsOn = dependsOn
+	svc.UpdatedAt = time.Now()
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.serviceRepo.SetDependencies(ctx, serviceID, dependsOn); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.ServiceEvent(events.ServiceUpdated, svc)}, nil
+	})
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+	return &response.DependenciesResDto{ServiceID: serviceID, DependsOn: dependsOn}, nil
+}
+
+func (s *dependencyService) Graph(ctx context.Context, req request.DependencyGraphRequest) (*response.DependencyGraphResDto, error) {
+	graph, err := loadDependencyGraph(ctx, s.serviceRepo, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	impacted := make(map[string]bool)
+	for _, impact := range graph.Impacted(downServiceIDs(graph.Services())...) {
+		impacted[impact.Service.ID.Hex()] = true
+	}
+
+	res := &response.DependencyGraphResDto{
+		Nodes: make([]response.DependencyNodeResDto, 0),
+		Edges: make([]response.DependencyEdgeResDto, 0),
+	}
+	for _, svc := range graph.Services() {
+		node := response.DependencyNodeResDto{
+			ID:             svc.ID.Hex(),
+			Title:          svc.Title,
+			OrganizationID: svc.OrganizationID,
+			Status:         svc.Status,
+			Impacted:       impacted[svc.ID.Hex()],
+		}
+		if svc.Health != nil {
+			node.HealthStatus = svc.Health.Status
+		}
+		res.Nodes = append(res.Nodes, node)
+	}
+	for _, edge := range graph.Edges() {
+		res.Edges = append(res.Edges, response.DependencyEdgeResDto{From: edge[0], To: edge[1]})
+	}
+	return res, nil
+}
+
+func (s *dependencyService) GraphDOT(ctx context.Context, req request.DependencyGraphRequest) (string, error) {
+	graph, err := loadDependencyGraph(ctx, s.serviceRepo, req.OrganizationID)
+	if err != nil {
+		return "", err
+	}
+	return graph.DOT(), nil
+}
+
+func (s *dependencyService) Impact(ctx context.Context, serviceID string) (*response.ImpactResDto, error) {
+	graph, err := loadDependencyGraph(ctx, s.serviceRepo, "")
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := graph.Service(serviceID)
+	if !ok {
+		return nil, ErrServiceNotFound
+	}
+	return impactResDto(svc, graph.Impacted(serviceID)), nil
+}
+
+func (s *dependencyService) ReportStatusChange(ctx context.Context, svc *model.Service, state model.HealthState) {
+	if state.Status != model.HealthStatusDown {
+		return
+	}
+	impact, err := s.Impact(ctx, svc.ID.Hex())
+	if err != nil {
+		logger.WriteLogEx("error", "compute service impact failed", map[string]any{
+			"service_id": svc.ID.Hex(),
+			"error":      err.Error(),
+		})
+		return
+	}
+	if len(impact.Impacted) == 0 {
+		return
+	}
+
+	impacted := make([]string, 0, len(impact.Impacted))
+	for _, i := range impact.Impacted {
+		impacted = append(impacted, i.ServiceID+" ("+i.Title+")")
+	}
+	logger.WriteLogEx("warn", "service down impacts dependent services", map[string]any{
+		"service_id": svc.ID.Hex(),
+		"title":      svc.Title,
+		"error":      state.Error,
+		"impacted":   impacted,
+	})
+}
+
+// loadDependencyGraph đồ thị của mọi service (kể cả inactive) thấy được từ organizationID
+func loadDependencyGraph(ctx context.Context, serviceRepo repository.ServiceRepository, organizationID string) (*dependency.Graph, error) {
+	services, _, err := serviceRepo.Find(ctx, repository.ServiceFilter{OrganizationID: organizationID}, repository.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return dependency.NewGraph(services), nil
+}
+
+func impactResDto(svc *model.Service, impacts []dependency.Impact) *response.ImpactResDto {
+	res := &response.ImpactResDto{
+		ServiceID: svc.ID.Hex(),
+		Title:     svc.Title,
+		Impacted:  impactedResDtos(impacts),
+	}
+	if svc.Health != nil {
+		res.HealthStatus = svc.Health.Status
+	}
+	return res
+}
+
+func impactedResDtos(impacts []dependency.Impact) []response.ImpactedServiceResDto {
+	result := make([]response.ImpactedServiceResDto, 0, len(impacts))
+	for _, impact := range impacts {
+		result = append(result, response.ImpactedServiceResDto{
+			ServiceID: impact.Service.ID.Hex(),
+			Title:     impact.Service.Title,
+			Depth:     impact.Depth,
+			Path:      impact.Path,
+		})
+	}
+	return result
+}
+
+func downServiceIDs(services []*model.Service) []string {
+	var ids []string
+	for _, svc := range services {
+		if svc.Health != nil && svc.Health.Status == model.HealthStatusDown {
+			ids = append(ids, svc.ID.Hex())
+		}
+	}
+	return ids
+}
+
+func serviceTitles(graph *dependency.Graph, ids []string) []string {
+	titles := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if svc, ok := graph.Service(id); ok {
+			titles = append(titles, svc.Title)
+		} else {
+			titles = append(titles, id)
+		}
+	}
+	return titles
+}
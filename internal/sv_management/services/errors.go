@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"services-management/internal/sv_management/sso"
+	"services-management/pkg/urltemplate"
+	"time"
+)
+
+var (
+	ErrInvalidAsset            = errors.New("invalid asset: only png, jpeg, gif, webp and svg images are allowed")
+	ErrAssetTooLarge           = errors.New("asset exceeds the maximum upload size")
+	ErrAssetTooLargeDimensions = errors.New("asset exceeds the maximum image width or height")
+	ErrAssetNotFound           = errors.New("asset not found")
+	ErrAssetInUse              = errors.New("asset is used as an icon by a service or group")
+
+	ErrServiceNotFound         = errors.New("service not found")
+	ErrServiceGroupNotFound    = errors.New("service group not found")
+	ErrUnsupportedLocale       = errors.New("unsupported locale")
+	ErrParentGroupNotFound     = errors.New("parent service group not found")
+	ErrGroupCycle              = errors.New("a group cannot be moved under itself or its descendants")
+	ErrParentGroupOrganization = errors.New("parent service group belongs to another organization")
+	ErrGroupTreeLocked         = errors.New("service groups are being moved by another request, try again")
+	ErrInvalidReorder          = errors.New("group_ids must be exactly the children of parent_id")
+	ErrInvalidDateRange        = errors.New("from must not be after to")
+	ErrInvalidFavorites        = errors.New("service_ids must be exactly the current favorites")
+
+	ErrMissingEnvironmentUrl     = errors.New("missing url for required environment")
+	ErrServiceNotProbeable       = errors.New("service url cannot be probed, set probe_url or enable the health check")
+	ErrInvalidAnnouncementWindow = errors.New("ends_at must be after starts_at")
+	ErrMaintenanceEndRequired    = errors.New("maintenance requires ends_at")
+	ErrAnnouncementNotFound      = errors.New("announcement not found")
+	ErrServiceUnderMaintenance   = errors.New("service is under maintenance")
+	ErrSSONotConfigured          = errors.New("sso is not configured for this service")
+	ErrSSOTokenReplayed          = errors.New("sso token has already been used or has expired")
+	ErrInvalidWebhook            = errors.New("invalid webhook subscription")
+	ErrWebhookNotFound           = errors.New("webhook subscription not found")
+	ErrDeadLetterNotFound        = errors.New("dead-letter delivery not found")
+	ErrServiceGroupNotEmpty      = errors.New("service group still has child groups or services")
+	ErrEventStoreDisabled        = errors.New("event store is not configured")
+	ErrInvalidStream             = errors.New("invalid aggregate type")
+	ErrCollectionNotFound        = errors.New("smart collection not found")
+	ErrEmptyCollectionQuery      = errors.New("collection query needs at least one of tags, roles or organization_ids")
+	ErrTagNotFound               = errors.New("tag not found")
+	ErrInvalidTag                = errors.New("tag must not be empty")
+	ErrDependencyNotFound        = errors.New("dependency service not found")
+	ErrDependencyCycle           = errors.New("dependencies would create a cycle")
+	ErrDependencyLocked          = errors.New("dependencies are being updated by another request, try again")
+	ErrOrganizationNotFound      = errors.New("organization not found")
+
+	// lỗi của sso, giữ nguyên chi tiết từ package sso
+	ErrInvalidSSOConfig = sso.ErrInvalidConfig
+	ErrInvalidSSOToken  = sso.ErrInvalidToken
+
+	// lỗi của url template, giữ nguyên chi tiết từ urltemplate
+	ErrInvalidUrlTemplate = urltemplate.ErrInvalidTemplate
+	ErrUrlValueMissing    = urltemplate.ErrMissingValue
+)
+
+// MaintenanceError launch bị chặn bởi lịch bảo trì, handler dùng Until cho Retry-After
+type MaintenanceError struct {
+	Until   *time.Time
+	Message string
+}
+
+func (e *MaintenanceError) Error() string {
+	return ErrServiceUnderMaintenance.Error() + ": " + e.Message
+}
+
+func (e *MaintenanceError) Unwrap() error {
+	return ErrServiceUnderMaintenance
+}
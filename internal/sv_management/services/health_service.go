@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/health"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"sort"
+	"time"
+)
+
+const defaultHealthHistoryLimit = 50
+
+type HealthService interface {
+	FailingServices(ctx context.Context, req request.FailingServicesRequest) ([]*response.FailingServiceResDto, error)
+	History(ctx context.Context, serviceID string, req request.HealthHistoryRequest) ([]*response.HealthCheckResDto, error)
+	ConfigureCheck(ctx context.Context, serviceID string, req request.HealthCheckConfigRequest) (*response.HealthCheckConfigResDto, error)
+	CheckNow(ctx context.Context, serviceID string) (*response.HealthCheckResDto, error)
+	// MarkStatus admin đặt trạng thái up/down, trả về các service bị ảnh hưởng
+	MarkStatus(ctx context.Context, serviceID string, req request.MarkHealthStatusRequest) (*response.ImpactResDto, error)
+	RunAll(ctx context.Context) error
+}
+
+type healthService struct {
+	serviceRepo repository.ServiceRepository
+	historyRepo repository.HealthCheckRepository
+	job         *health.Job
+	dependency  DependencyService
+	recorder    events.Recorder
+}
+
+func NewHealthService(serviceRepo repository.ServiceRepository, historyRepo repository.HealthCheckRepository, job *health.Job, dependency DependencyService, recorder events.Recorder) HealthService {
+	return &healthService{
+		serviceRepo: serviceRepo,
+		historyRepo: historyRepo,
+		job:         job,
+		dependency:  dependency,
+		recorder:    recorder,
+	}
+}
+
+// FailingServices service đang down, lỗi lâu nhất đứng đầu
+func (s *healthService) FailingServices(ctx context.Context, req request.FailingServicesRequest) ([]*response.FailingServiceResDto, error) {
+	services, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{
+		OrganizationID: req.OrganizationID,
+		HealthStatus:   model.HealthStatusDown,
+	}, repository.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	graph, err := loadDependencyGraph(ctx, s.serviceRepo, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.FailingServiceResDto, 0, len(services))
+	for _, svc := range services {
+		result = append(result, &response.FailingServiceResDto{
+			ServiceID:        svc.ID.Hex(),
+			Title:            svc.Title,
+			Url:              svc.Url,
+			OrganizationID:   svc.OrganizationID,
+			StatusCode:       svc.Health.StatusCode,
+			Error:            svc.Health.Error,
+			LastChecked:      svc.Health.LastChecked,
+			FailingSince:     svc.Health.ChangedAt,
+			ImpactedServices: impactedResDtos(graph.Impacted(svc.ID.Hex())),
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FailingSince.Before(result[j].FailingSince)
+	})
+	return result, nil
+}
+
+func (s *healthService) History(ctx context.Context, serviceID string, req request.HealthHistoryRequest) ([]*response.HealthCheckResDto, error) {
+	if _, err := s.serviceRepo.GetByID(ctx, serviceID); err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultHealthHistoryLimit
+	}
+	checks, err := s.historyRepo.History(ctx, serviceID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.HealthCheckResDto, 0, len(checks))
+	for _, check := range checks {
+		result = append(result, healthCheckResDto(check))
+	}
+	return result, nil
+}
+
+func (s *healthService) ConfigureCheck(ctx context.Context, serviceID string, req request.HealthCheckConfigRequest) (*response.HealthCheckConfigResDto, error) {
+	cfg := &model.HealthCheckConfig{
+		Disabled:       req.Disabled,
+		Method:         req.Method,
+		ProbeUrl:       req.ProbeUrl,
+		TimeoutMs:      req.TimeoutMs,
+		ExpectedStatus: req.ExpectedStatus,
+		Keyword:        req.Keyword,
+	}
+	err := recordWrite(ctx, s.recorder, func(ctx context.Context) error {
+		return s.serviceRepo.SetHealthCheck(ctx, serviceID, cfg)
+	})
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+
+	return &response.HealthCheckConfigResDto{
+		ServiceID:      serviceID,
+		Disabled:       cfg.Disabled,
+		Method:         cfg.Method,
+		ProbeUrl:       cfg.ProbeUrl,
+		TimeoutMs:      cfg.TimeoutMs,
+		ExpectedStatus: cfg.ExpectedStatus,
+		Keyword:        cfg.Keyword,
+	}, nil
+}
+
+func (s *healthService) CheckNow(ctx context.Context, serviceID string) (*response.HealthCheckResDto, error) {
+	svc, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+
+	check := s.job.CheckService(ctx, svc)
+	if check == nil {
+		return nil, ErrServiceNotProbeable
+	}
+	return healthCheckResDto(check), nil
+}
+
+func (s *healthService) MarkStatus(ctx context.Context, serviceID string, req request.MarkHealthStatusRequest) (*response.ImpactResDto, error) {
+	svc, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+
+	state := model.HealthState{
+		Status:      req.Status,
+		LastChecked: time.Now(),
+		Error:       req.Reason,
+	}
+	err = recordWrite(ctx, s.recorder, func(ctx context.Context) error {
+		return s.serviceRepo.SetHealth(ctx, serviceID, state)
+	})
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+	if svc.Health == nil || svc.Health.Status != state.Status {
+		s.dependency.ReportStatusChange(ctx, svc, state)
+	}
+
+	impact, err := s.dependency.Impact(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Status != model.HealthStatusDown {
+		impact.Impacted = []response.ImpactedServiceResDto{}
+	}
+	return impact, nil
+}
+
+func (s *healthService) RunAll(ctx context.Context) error {
+	return s.job.Run(ctx)
+}
+
+func healthCheckResDto(check *model.HealthCheck) *response.HealthCheckResDto {
+	return &response.HealthCheckResDto{
+		Url:        check.Url,
+		Status:     check.Status,
+		StatusCode: check.StatusCode,
+		LatencyMs:  check.LatencyMs,
+		Error:      check.Error,
+		CheckedAt:  check.CheckedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/gateway"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/internal/sv_management/sso"
+	"services-management/internal/sv_management/tracking"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LaunchService interface {
+	// Launch kiểm tra user có được thấy service không, ghi click và trả về url để redirect
+	Launch(ctx context.Context, serviceID, userAgent string) (string, error)
+}
+
+type launchService struct {
+	serviceRepo repository.ServiceRepository
+	tracker     tracking.Tracker
+	userGateway gateway.UserGateway
+	nonceRepo   repository.SSONonceRepository
+
+	serviceGroupRepo repository.ServiceGroupRepository
+	announcementRepo repository.AnnouncementRepository
+}
+
+func NewLaunchService(
+	serviceRepo repository.ServiceRepository,
+	tracker tracking.Tracker,
+	userGateway gateway.UserGateway,
+	nonceRepo repository.SSONonceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	announcementRepo repository.AnnouncementRepository,
+) LaunchService {
+	return &launchService{
+		serviceRepo:      serviceRepo,
+		tracker:          tracker,
+		userGateway:      userGateway,
+		nonceRepo:        nonceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		announcementRepo: announcementRepo,
+	}
+}
+
+func (s *launchService) Launch(ctx context.Context, serviceID, userAgent string) (string, error) {
+	svc, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return "", ErrServiceNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	user, err := currentUser(ctx, s.userGateway)
+	if err != nil {
+		return "", err
+	}
+	organizationID := user.OrganizationIdActive
+
+	roles := rolesFromContext(ctx)
+	// service user không được thấy trả về not found, không lộ là service có tồn tại
+	if !isVisible(svc, organizationID, roles) {
+		return "", ErrServiceNotFound
+	}
+
+	if err := s.checkMaintenance(ctx, svc); err != nil {
+		return "", err
+	}
+
+	// url có placeholder thì render theo user, thiếu giá trị thì không redirect tới link hỏng
+	values := newURLValues(ctx, s.userGateway, user)
+	url, err := values.render(environmentUrl(ctx, svc.Url, svc.Urls))
+	if err != nil {
+		return "", err
+	}
+
+	// service của đối tác nhận danh tính qua launch token ngắn hạn thay vì JWT của mình.
+	// Đối tác tin token đã ký nên danh tính chỉ lấy từ user service, không lấy từ request
+	if svc.SSO != nil && svc.SSO.Enabled {
+		url, err = issueLaunchToken(ctx, s.nonceRepo, svc, url, sso.Identity{
+			UserID:         user.ID,
+			Name:           user.Username,
+			Roles:          roles,
+			OrganizationID: user.OrganizationIdActive,
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	s.tracker.Track(&model.ClickEvent{
+		ServiceID:      serviceID,
+		UserID:         userIDFromContext(ctx),
+		OrganizationID: organizationID,
+		Roles:          roles,
+		UserAgent:      userAgent,
+		ClickedAt:      time.Now(),
+	})
+	return url, nil
+}
+
+// checkMaintenance chặn launch khi service hoặc group chứa nó đang bảo trì với disable_launch
+func (s *launchService) checkMaintenance(ctx context.Context, svc *model.Service) error {
+	announcements, err := loadAnnouncements(ctx, s.announcementRepo)
+	if err != nil {
+		return err
+	}
+
+	groups := map[string]*model.ServiceGroup{}
+	if svc.GroupID != "" {
+		group, err := s.serviceGroupRepo.GetByID(ctx, svc.GroupID)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) && !errors.Is(err, primitive.ErrInvalidHex) {
+			return err
+		}
+		if group != nil {
+			groups[svc.GroupID] = group
+		}
+	}
+
+	if a := announcements.blocking(svc.ID.Hex(), groupChain(svc.GroupID, groups)); a != nil {
+		return maintenanceError(a)
+	}
+	return nil
+}
+
+// isVisible cùng điều kiện với catalog của user (xem visibleServices)
+func isVisible(svc *model.Service, organizationID string, roles []string) bool {
+	if !isActive(svc.Status) {
+		return false
+	}
+	return sameOrganization(svc.OrganizationID, organizationID) && hasAnyRole(svc.Roles, roles)
+}
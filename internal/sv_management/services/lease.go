@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	writeLeaseTTL   = 30 * time.Second
+	writeLeaseWait  = 5 * time.Second
+	writeLeaseRetry = 50 * time.Millisecond
+)
+
+// holdLease giữ lease name để các lần ghi cần kiểm tra trên toàn bộ dữ liệu chạy tuần tự giữa các instance.
+// Chờ tối đa writeLeaseWait nếu request khác đang giữ, quá thời gian thì trả về locked
+func holdLease(ctx context.Context, leaseRepo repository.LeaseRepository, name string, locked error) (func(), error) {
+	owner := primitive.NewObjectID().Hex()
+	deadline := time.Now().Add(writeLeaseWait)
+	for {
+		ok, err := leaseRepo.Acquire(ctx, name, owner, writeLeaseTTL)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, locked
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(writeLeaseRetry):
+		}
+	}
+
+	return func() {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := leaseRepo.Release(releaseCtx, name, owner); err != nil {
+			logger.WriteLogEx("error", "release lease failed", map[string]any{
+				"lease": name,
+				"error": err.Error(),
+			})
+		}
+	}, nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/gateway"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"services-management/pkg/i18n"
+	"sort"
+	"time"
+)
+
+type OwnershipService interface {
+	SetOwnership(ctx context.Context, serviceID string, req request.OwnershipRequest) (*response.OwnershipResDto, error)
+	ClearOwnership(ctx context.Context, serviceID string) error
+	// ServicesByOwner gom service theo owner_team + owner_staff_id, nhóm chưa có owner ở cuối
+	ServicesByOwner(ctx context.Context, req request.OwnerServicesRequest) ([]*response.OwnerServicesResDto, error)
+	// Support thông tin liên hệ của service cho user, service user không được thấy trả về not found
+	Support(ctx context.Context, serviceID string) (*response.ServiceSupportResDto, error)
+}
+
+type ownershipService struct {
+	serviceRepo    repository.ServiceRepository
+	userGateway    gateway.UserGateway
+	localeResolver *i18n.Resolver
+	recorder       events.Recorder
+}
+
+func NewOwnershipService(
+	serviceRepo repository.ServiceRepository,
+	userGateway gateway.UserGateway,
+	localeResolver *i18n.Resolver,
+	recorder events.Recorder,
+) OwnershipService {
+	return &ownershipService{
+		serviceRepo:    serviceRepo,
+		userGateway:    userGateway,
+		localeResolver: localeResolver,
+		recorder:       recorder,
+	}
+}
+
+func (s *ownershipService) SetOwnership(ctx context.Context, serviceID string, req request.OwnershipRequest) (*response.OwnershipResDto, error) {
+	ownership := mapper.MapOwnershipRequest(&req)
+	if err := s.save(ctx, serviceID, ownership); err != nil {
+		return nil, err
+	}
+	return mapper.MapOwnershipResDto(ownership), nil
+}
+
+func (s *ownershipService) ClearOwnership(ctx context.Context, serviceID string) error {
+	return s.save(ctx, serviceID, nil)
+}
+
+func (s *ownershipService) save(ctx context.Context, serviceID string, ownership *model.Ownership) error {
+	svc, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return mapServiceNotFound(err)
+	}
+	svc.Ownership = ownership
+	svc.UpdatedAt = time.Now()
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.serviceRepo.SetOwnership(ctx, serviceID, ownership); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.ServiceEvent(events.ServiceUpdated, svc)}, nil
+	})
+	return mapServiceNotFound(err)
+}
+
+func (s *ownershipService) ServicesByOwner(ctx context.Context, req request.OwnerServicesRequest) ([]*response.OwnerServicesResDto, error) {
+	services, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{
+		OrganizationID: req.OrganizationID,
+		OwnerTeam:      req.OwnerTeam,
+		OwnerStaffID:   req.OwnerStaffID,
+	}, repository.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	type ownerKey struct{ team, staffID string }
+	groups := make(map[ownerKey]*response.OwnerServicesResDto)
+	locales := s.localeResolver.Chain(req.Locales...)
+	for _, svc := range services {
+		key := ownerKey{}
+		if svc.Ownership != nil {
+			key = ownerKey{svc.Ownership.OwnerTeam, svc.Ownership.OwnerStaffID}
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &response.OwnerServicesResDto{OwnerTeam: key.team, OwnerStaffID: key.staffID}
+			groups[key] = group
+		}
+		group.Services = append(group.Services, *mapper.MapServiceToServiceResDto(*svc, locales...))
+	}
+
+	result := make([]*response.OwnerServicesResDto, 0, len(groups))
+	staffIDs := make([]string, 0, len(groups))
+	for key, group := range groups {
+		result = append(result, group)
+		if key.staffID != "" {
+			staffIDs = append(staffIDs, key.staffID)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.OwnerTeam == "") != (b.OwnerTeam == "") {
+			return b.OwnerTeam == ""
+		}
+		if a.OwnerTeam != b.OwnerTeam {
+			return a.OwnerTeam < b.OwnerTeam
+		}
+		return a.OwnerStaffID < b.OwnerStaffID
+	})
+
+	contacts := staffContacts(ctx, s.userGateway, staffIDs)
+	for _, group := range result {
+		group.Owner = contacts[group.OwnerStaffID]
+		for i := range group.Services {
+			if o := group.Services[i].Ownership; o != nil {
+				o.Owner = group.Owner
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *ownershipService) Support(ctx context.Context, serviceID string) (*response.ServiceSupportResDto, error) {
+	svc, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+	user, err := currentUser(ctx, s.userGateway)
+	if err != nil {
+		return nil, err
+	}
+	if !isVisible(svc, user.OrganizationIdActive, rolesFromContext(ctx)) {
+		return nil, ErrServiceNotFound
+	}
+
+	res := &response.ServiceSupportResDto{
+		ServiceID: svc.ID.Hex(),
+		Title:     svc.Title,
+		Ownership: mapper.MapOwnershipResDto(svc.Ownership),
+	}
+	if res.Ownership != nil && res.Ownership.OwnerStaffID != "" {
+		res.Ownership.Owner = staffContacts(ctx, s.userGateway, []string{res.Ownership.OwnerStaffID})[res.Ownership.OwnerStaffID]
+	}
+	return res, nil
+}
+
+// staffContacts lấy tên/ảnh của nhân sự phụ trách, user service lỗi thì bỏ qua
+// để vẫn trả được email/điện thoại đã lưu trên service
+func staffContacts(ctx context.Context, userGateway gateway.UserGateway, staffIDs []string) map[string]*response.StaffContactResDto {
+	result := make(map[string]*response.StaffContactResDto, len(staffIDs))
+	for _, id := range staffIDs {
+		if _, ok := result[id]; ok {
+			continue
+		}
+		staff, err := userGateway.GetStaffInfo(ctx, id)
+		if err != nil || staff == nil {
+			if err != nil {
+				logger.WriteLogEx("warn", "get staff info failed", map[string]any{
+					"staff_id": id,
+					"error":    err.Error(),
+				})
+			}
+			result[id] = nil
+			continue
+		}
+		result[id] = &response.StaffContactResDto{
+			ID:        staff.ID,
+			Name:      staff.Name,
+			AvatarUrl: staff.Avatar.ImageUrl,
+		}
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/eventstore"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"slices"
+)
+
+const defaultHistoryLimit = 100
+
+var streamAggregateTypes = []string{"service", "group", "announcement", "collection"}
+
+type ProjectionService interface {
+	// Status checkpoint của projection catalog
+	Status(ctx context.Context) (*response.ProjectionStatusResDto, error)
+	// Replay yêu cầu dựng lại read model catalog từ đầu event store
+	Replay(ctx context.Context) error
+	// Views read model catalog của một organization
+	Views(ctx context.Context, req request.CatalogViewRequest) ([]*model.CatalogView, error)
+	// History event trong stream của một service/group/announcement/collection
+	History(ctx context.Context, aggregateType, aggregateID string, req request.StreamHistoryRequest) (*response.StreamHistoryResDto, error)
+}
+
+type projectionService struct {
+	store      eventstore.Store
+	projection *eventstore.Projection
+	viewRepo   repository.CatalogViewRepository
+}
+
+// NewProjectionService store hoặc projection nil nghĩa là chưa cấu hình event store
+func NewProjectionService(store eventstore.Store, projection *eventstore.Projection, viewRepo repository.CatalogViewRepository) ProjectionService {
+	return &projectionService{
+		store:      store,
+		projection: projection,
+		viewRepo:   viewRepo,
+	}
+}
+
+func (s *projectionService) Status(ctx context.Context) (*response.ProjectionStatusResDto, error) {
+	if s.projection == nil {
+		return nil, ErrEventStoreDisabled
+	}
+
+	checkpoint, err := s.projection.Checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &response.ProjectionStatusResDto{
+		Name:            checkpoint.Name,
+		Started:         checkpoint.Started,
+		Processed:       checkpoint.Processed,
+		ReplayRequested: checkpoint.ReplayRequested,
+		UpdatedAt:       checkpoint.UpdatedAt,
+	}
+	if checkpoint.Started {
+		status.Position = &eventstore.Position{Commit: checkpoint.Commit, Prepare: checkpoint.Prepare}
+	}
+	return status, nil
+}
+
+func (s *projectionService) Replay(ctx context.Context) error {
+	if s.projection == nil {
+		return ErrEventStoreDisabled
+	}
+	return s.projection.Replay(ctx)
+}
+
+func (s *projectionService) Views(ctx context.Context, req request.CatalogViewRequest) ([]*model.CatalogView, error) {
+	if s.projection == nil {
+		return nil, ErrEventStoreDisabled
+	}
+	return s.viewRepo.FindByOrganization(ctx, req.OrganizationID, req.AggregateType)
+}
+
+func (s *projectionService) History(ctx context.Context, aggregateType, aggregateID string, req request.StreamHistoryRequest) (*response.StreamHistoryResDto, error) {
+	if s.store == nil {
+		return nil, ErrEventStoreDisabled
+	}
+	if !slices.Contains(streamAggregateTypes, aggregateType) {
+		return nil, ErrInvalidStream
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	stream := eventstore.StreamName(aggregateType, aggregateID)
+	records, err := s.store.ReadStream(ctx, stream, req.From, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &response.StreamHistoryResDto{
+		Stream: stream,
+		Events: records,
+	}
+	if len(records) == limit {
+		next := records[len(records)-1].Version + 1
+		history.Next = &next
+	}
+	return history, nil
+}
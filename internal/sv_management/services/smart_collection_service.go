@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/pkg/constants"
+	"services-management/pkg/i18n"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SmartCollectionService interface {
+	Create(ctx context.Context, req request.SmartCollectionRequest) (*response.SmartCollectionResDto, error)
+	Update(ctx context.Context, id string, req request.SmartCollectionRequest) (*response.SmartCollectionResDto, error)
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*response.SmartCollectionResDto, error)
+	List(ctx context.Context, req request.ListSmartCollectionsRequest) ([]*response.SmartCollectionResDto, error)
+	// Services xem trước các service active khớp collection, không lọc theo role của user
+	Services(ctx context.Context, id string, req request.CollectionServicesRequest) ([]*response.ServiceResDto, error)
+}
+
+type smartCollectionService struct {
+	collectionRepo repository.SmartCollectionRepository
+	serviceRepo    repository.ServiceRepository
+	localeResolver *i18n.Resolver
+	recorder       events.Recorder
+}
+
+func NewSmartCollectionService(
+	collectionRepo repository.SmartCollectionRepository,
+	serviceRepo repository.ServiceRepository,
+	localeResolver *i18n.Resolver,
+	recorder events.Recorder,
+) SmartCollectionService {
+	return &smartCollectionService{
+		collectionRepo: collectionRepo,
+		serviceRepo:    serviceRepo,
+		localeResolver: localeResolver,
+		recorder:       recorder,
+	}
+}
+
+func (s *smartCollectionService) Create(ctx context.Context, req request.SmartCollectionRequest) (*response.SmartCollectionResDto, error) {
+	collection := &model.SmartCollection{
+		ID:        primitive.NewObjectID(),
+		CreatedBy: userIDFromContext(ctx),
+	}
+	if err := s.apply(collection, req); err != nil {
+		return nil, err
+	}
+	err := s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.collectionRepo.Create(ctx, collection); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.CollectionEvent(events.CollectionCreated, collection)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapSmartCollectionResDto(collection), nil
+}
+
+func (s *smartCollectionService) Update(ctx context.Context, id string, req request.SmartCollectionRequest) (*response.SmartCollectionResDto, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, mapCollectionNotFound(err)
+	}
+	if err := s.apply(collection, req); err != nil {
+		return nil, err
+	}
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.collectionRepo.Update(ctx, collection); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.CollectionEvent(events.CollectionUpdated, collection)}, nil
+	})
+	if err != nil {
+		return nil, mapCollectionNotFound(err)
+	}
+	return mapper.MapSmartCollectionResDto(collection), nil
+}
+
+func (s *smartCollectionService) Delete(ctx context.Context, id string) error {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err == nil {
+		err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+			if err := s.collectionRepo.Delete(ctx, id); err != nil {
+				return nil, err
+			}
+			return []events.Event{events.CollectionEvent(events.CollectionDeleted, collection)}, nil
+		})
+	}
+	return mapCollectionNotFound(err)
+}
+
+func (s *smartCollectionService) Get(ctx context.Context, id string) (*response.SmartCollectionResDto, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, mapCollectionNotFound(err)
+	}
+	return mapper.MapSmartCollectionResDto(collection), nil
+}
+
+func (s *smartCollectionService) List(ctx context.Context, req request.ListSmartCollectionsRequest) ([]*response.SmartCollectionResDto, error) {
+	collections, err := s.collectionRepo.Find(ctx, repository.SmartCollectionFilter{OrganizationID: req.OrganizationID})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*response.SmartCollectionResDto, 0, len(collections))
+	for _, c := range collections {
+		result = append(result, mapper.MapSmartCollectionResDto(c))
+	}
+	return result, nil
+}
+
+func (s *smartCollectionService) Services(ctx context.Context, id string, req request.CollectionServicesRequest) ([]*response.ServiceResDto, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, mapCollectionNotFound(err)
+	}
+	services, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{
+		OrganizationID: collection.OrganizationID,
+		Status:         string(constants.ServiceStatusActive),
+	}, repository.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapServicesToServiceResDtos(collectionServices(collection, services), s.localeResolver.Chain(req.Locales...)...), nil
+}
+
+// apply kiểm tra request rồi ghi vào collection
+func (s *smartCollectionService) apply(collection *model.SmartCollection, req request.SmartCollectionRequest) error {
+	translations, err := buildTranslations(s.localeResolver, req.Translations)
+	if err != nil {
+		return err
+	}
+	query := model.CollectionQuery{
+		Tags:            normalizeTags(req.Query.Tags),
+		MatchAllTags:    req.Query.MatchAllTags,
+		Roles:           uniqueValues(req.Query.Roles),
+		OrganizationIDs: uniqueValues(req.Query.OrganizationIDs),
+	}
+	if query.IsEmpty() {
+		return ErrEmptyCollectionQuery
+	}
+
+	collection.Title = strings.TrimSpace(req.Title)
+	collection.OrganizationID = req.OrganizationID
+	collection.Order = req.Order
+	collection.Limit = req.Limit
+	collection.Query = query
+	collection.Translations = translations
+	return nil
+}
+
+// collectionServices giữ thứ tự của services, cắt theo limit của collection
+func collectionServices(collection *model.SmartCollection, services []*model.Service) []*model.Service {
+	result := make([]*model.Service, 0)
+	for _, svc := range services {
+		if collection.Query.Matches(svc) {
+			result = append(result, svc)
+			if collection.Limit > 0 && len(result) == collection.Limit {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// uniqueValues trim và bỏ giá trị rỗng/trùng, giữ nguyên chữ hoa thường
+func uniqueValues(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
+
+func mapCollectionNotFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
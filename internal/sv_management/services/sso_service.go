@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/internal/sv_management/sso"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ssoParamPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+type SSOService interface {
+	Configure(ctx context.Context, serviceID string, req request.SSOConfigRequest) (*response.SSOConfigResDto, error)
+	GetConfig(ctx context.Context, serviceID string) (*response.SSOConfigResDto, error)
+	Disable(ctx context.Context, serviceID string) error
+	// Verify dành cho đối tác: kiểm tra token và đánh dấu nonce đã dùng
+	Verify(ctx context.Context, req request.VerifySSOTokenRequest) (*response.VerifySSOTokenResponse, error)
+}
+
+type ssoService struct {
+	serviceRepo repository.ServiceRepository
+	nonceRepo   repository.SSONonceRepository
+	recorder    events.Recorder
+}
+
+func NewSSOService(serviceRepo repository.ServiceRepository, nonceRepo repository.SSONonceRepository, recorder events.Recorder) SSOService {
+	return &ssoService{
+		serviceRepo: serviceRepo,
+		nonceRepo:   nonceRepo,
+		recorder:    recorder,
+	}
+}
+
+func (s *ssoService) Configure(ctx context.Context, serviceID string, req request.SSOConfigRequest) (*response.SSOConfigResDto, error) {
+	if _, err := s.getService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	if err := sso.ValidateClaimMapping(req.ClaimMapping); err != nil {
+		return nil, err
+	}
+	if req.Param != "" && !ssoParamPattern.MatchString(req.Param) {
+		return nil, fmt.Errorf("%w: param may only contain letters, digits, '_' and '-'", ErrInvalidSSOConfig)
+	}
+
+	cfg := &model.SSOConfig{
+		Enabled:      true,
+		Algorithm:    req.Algorithm,
+		Audience:     req.Audience,
+		ClaimMapping: req.ClaimMapping,
+		TTLSeconds:   req.TTLSeconds,
+		Param:        req.Param,
+		UpdatedAt:    time.Now(),
+	}
+
+	generatedSecret := ""
+	switch req.Algorithm {
+	case sso.AlgorithmHS256:
+		cfg.Secret = req.Secret
+		if cfg.Secret == "" {
+			secret, err := sso.GenerateSecret()
+			if err != nil {
+				return nil, err
+			}
+			cfg.Secret, generatedSecret = secret, secret
+		}
+	case sso.AlgorithmRS256:
+		cfg.PrivateKey = req.PrivateKey
+		if cfg.PrivateKey == "" {
+			key, err := sso.GeneratePrivateKey()
+			if err != nil {
+				return nil, err
+			}
+			cfg.PrivateKey = key
+		}
+	}
+	if err := sso.ValidateKey(cfg); err != nil {
+		return nil, err
+	}
+
+	err := recordWrite(ctx, s.recorder, func(ctx context.Context) error {
+		return s.serviceRepo.SetSSO(ctx, serviceID, cfg)
+	})
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+
+	res, err := ssoConfigResDto(serviceID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	res.Secret = generatedSecret
+	return res, nil
+}
+
+func (s *ssoService) GetConfig(ctx context.Context, serviceID string) (*response.SSOConfigResDto, error) {
+	svc, err := s.getService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if svc.SSO == nil {
+		return nil, ErrSSONotConfigured
+	}
+	return ssoConfigResDto(serviceID, svc.SSO)
+}
+
+func (s *ssoService) Disable(ctx context.Context, serviceID string) error {
+	return mapServiceNotFound(recordWrite(ctx, s.recorder, func(ctx context.Context) error {
+		return s.serviceRepo.SetSSO(ctx, serviceID, nil)
+	}))
+}
+
+func (s *ssoService) Verify(ctx context.Context, req request.VerifySSOTokenRequest) (*response.VerifySSOTokenResponse, error) {
+	serviceID, err := sso.KeyID(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := s.getService(ctx, serviceID)
+	if errors.Is(err, ErrServiceNotFound) {
+		return nil, fmt.Errorf("%w: unknown service", ErrInvalidSSOToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if svc.SSO == nil || !svc.SSO.Enabled {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSSOToken, ErrSSONotConfigured)
+	}
+
+	claims, err := sso.Verify(req.Token, svc.SSO)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, _ := claims["jti"].(string)
+	ok, err := s.nonceRepo.Consume(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrSSOTokenReplayed
+	}
+	return &response.VerifySSOTokenResponse{ServiceID: serviceID, Claims: claims}, nil
+}
+
+func (s *ssoService) getService(ctx context.Context, serviceID string) (*model.Service, error) {
+	svc, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapServiceNotFound(err)
+	}
+	return svc, nil
+}
+
+// issueLaunchToken ký token cho user, lưu nonce rồi gắn token vào query của url
+func issueLaunchToken(ctx context.Context, nonceRepo repository.SSONonceRepository, svc *model.Service, rawUrl string, identity sso.Identity) (string, error) {
+	nonce, err := sso.NewNonce()
+	if err != nil {
+		return "", err
+	}
+	token, expiresAt, err := sso.Sign(svc.ID.Hex(), svc.SSO, identity, nonce, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if err := nonceRepo.Save(ctx, &model.SSONonce{
+		Nonce:     nonce,
+		ServiceID: svc.ID.Hex(),
+		UserID:    identity.UserID,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set(sso.Param(svc.SSO), token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func ssoConfigResDto(serviceID string, cfg *model.SSOConfig) (*response.SSOConfigResDto, error) {
+	res := &response.SSOConfigResDto{
+		ServiceID:    serviceID,
+		Enabled:      cfg.Enabled,
+		Algorithm:    cfg.Algorithm,
+		Audience:     cfg.Audience,
+		ClaimMapping: cfg.ClaimMapping,
+		TTLSeconds:   int(sso.TTL(cfg).Seconds()),
+		Param:        sso.Param(cfg),
+	}
+	if cfg.Algorithm == sso.AlgorithmRS256 {
+		publicKey, err := sso.PublicKeyPEM(cfg)
+		if err != nil {
+			return nil, err
+		}
+		res.PublicKey = publicKey
+	}
+	return res, nil
+}
+
+// recordWrite ghi thay đổi không sinh event qua recorder để cache catalog vẫn bị vô hiệu
+func recordWrite(ctx context.Context, recorder events.Recorder, write func(ctx context.Context) error) error {
+	return recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		return nil, write(ctx)
+	})
+}
+
+func mapServiceNotFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return ErrServiceNotFound
+	}
+	return err
+}
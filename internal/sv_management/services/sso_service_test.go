@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/sso"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryNonces nonce trong bộ nhớ, Consume xoá và kiểm tra hạn như bản mongo
+type memoryNonces struct {
+	mu     sync.Mutex
+	nonces map[string]model.SSONonce
+}
+
+func newMemoryNonces() *memoryNonces {
+	return &memoryNonces{nonces: make(map[string]model.SSONonce)}
+}
+
+func (r *memoryNonces) Save(_ context.Context, nonce *model.SSONonce) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nonces[nonce.Nonce] = *nonce
+	return nil
+}
+
+func (r *memoryNonces) Consume(_ context.Context, nonce string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved, ok := r.nonces[nonce]
+	delete(r.nonces, nonce)
+	return ok && saved.ExpiresAt.After(time.Now()), nil
+}
+
+func (r *memoryNonces) EnsureIndexes(context.Context) error {
+	return nil
+}
+
+func newSSOFixture() (*memoryServices, *memoryNonces, SSOService, *model.Service) {
+	services := &memoryServices{services: make(map[string]*model.Service)}
+	nonces := newMemoryNonces()
+	svc := newService("Partner")
+	svc.SSO = &model.SSOConfig{Enabled: true, Algorithm: sso.AlgorithmHS256, Secret: "0123456789abcdef0123456789abcdef"}
+	services.services[svc.ID.Hex()] = svc
+	return services, nonces, NewSSOService(services, nonces, nil), svc
+}
+
+// launchToken phát token như lúc user mở service rồi lấy lại từ url
+func launchToken(t *testing.T, nonces *memoryNonces, svc *model.Service) string {
+	t.Helper()
+	launchUrl, err := issueLaunchToken(context.Background(), nonces, svc, "https://partner.example.com/login", sso.Identity{UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(launchUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query().Get(sso.DefaultParam)
+}
+
+func TestVerifyConsumesNonce(t *testing.T) {
+	_, nonces, ssoService, svc := newSSOFixture()
+	token := launchToken(t, nonces, svc)
+
+	res, err := ssoService.Verify(context.Background(), request.VerifySSOTokenRequest{Token: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ServiceID != svc.ID.Hex() || res.Claims["sub"] != "u1" {
+		t.Fatalf("response = %+v, want service %s for user u1", res, svc.ID.Hex())
+	}
+
+	if _, err := ssoService.Verify(context.Background(), request.VerifySSOTokenRequest{Token: token}); !errors.Is(err, ErrSSOTokenReplayed) {
+		t.Fatalf("second verify error = %v, want %v", err, ErrSSOTokenReplayed)
+	}
+}
+
+func TestVerifyConcurrentReplay(t *testing.T) {
+	_, nonces, ssoService, svc := newSSOFixture()
+	token := launchToken(t, nonces, svc)
+
+	const attempts = 8
+	results := make(chan error, attempts)
+	for range attempts {
+		go func() {
+			_, err := ssoService.Verify(context.Background(), request.VerifySSOTokenRequest{Token: token})
+			results <- err
+		}()
+	}
+
+	accepted := 0
+	for range attempts {
+		err := <-results
+		switch {
+		case err == nil:
+			accepted++
+		case !errors.Is(err, ErrSSOTokenReplayed):
+			t.Fatalf("verify error = %v, want nil or %v", err, ErrSSOTokenReplayed)
+		}
+	}
+	if accepted != 1 {
+		t.Fatalf("token accepted %d times, want once", accepted)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		// token trả về token cần verify sau khi sửa dữ liệu của fixture
+		token func(t *testing.T, services *memoryServices, nonces *memoryNonces, svc *model.Service) string
+		want  error
+	}{
+		{
+			name: "nonce chưa từng phát",
+			token: func(t *testing.T, _ *memoryServices, _ *memoryNonces, svc *model.Service) string {
+				token, _, err := sso.Sign(svc.ID.Hex(), svc.SSO, sso.Identity{UserID: "u1"}, "forged", time.Now())
+				if err != nil {
+					t.Fatal(err)
+				}
+				return token
+			},
+			want: ErrSSOTokenReplayed,
+		},
+		{
+			name: "nonce đã hết hạn",
+			token: func(t *testing.T, _ *memoryServices, nonces *memoryNonces, svc *model.Service) string {
+				token := launchToken(t, nonces, svc)
+				for id, nonce := range nonces.nonces {
+					nonce.ExpiresAt = time.Now().Add(-time.Second)
+					nonces.nonces[id] = nonce
+				}
+				return token
+			},
+			want: ErrSSOTokenReplayed,
+		},
+		{
+			name: "service không tồn tại",
+			token: func(t *testing.T, services *memoryServices, nonces *memoryNonces, svc *model.Service) string {
+				token := launchToken(t, nonces, svc)
+				delete(services.services, svc.ID.Hex())
+				return token
+			},
+			want: ErrInvalidSSOToken,
+		},
+		{
+			name: "sso đã tắt",
+			token: func(t *testing.T, _ *memoryServices, nonces *memoryNonces, svc *model.Service) string {
+				token := launchToken(t, nonces, svc)
+				svc.SSO.Enabled = false
+				return token
+			},
+			want: ErrInvalidSSOToken,
+		},
+		{
+			name: "secret đã đổi",
+			token: func(t *testing.T, _ *memoryServices, nonces *memoryNonces, svc *model.Service) string {
+				token := launchToken(t, nonces, svc)
+				svc.SSO.Secret = "fedcba9876543210fedcba9876543210"
+				return token
+			},
+			want: ErrInvalidSSOToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services, nonces, ssoService, svc := newSSOFixture()
+			token := tt.token(t, services, nonces, svc)
+			if _, err := ssoService.Verify(context.Background(), request.VerifySSOTokenRequest{Token: token}); !errors.Is(err, tt.want) {
+				t.Fatalf("verify error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/sv_management/cache"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/health"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/pkg/constants"
+	"services-management/pkg/i18n"
+	"strings"
+	"time"
+)
+
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusOutage      = "outage"
+
+	// statusPageTTL status page công khai, tính lại tối đa mỗi phút
+	statusPageTTL = time.Minute
+)
+
+type StatusPageService interface {
+	GetStatusPage(ctx context.Context, req request.StatusPageRequest) (*response.StatusPageResponse, error)
+}
+
+type statusPageService struct {
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	healthRepo       repository.HealthCheckRepository
+	localeResolver   *i18n.Resolver
+	cache            *cache.Cache
+}
+
+// NewStatusPageService store giới hạn số trang giữ lại (LRU) vì endpoint không cần đăng nhập
+func NewStatusPageService(
+	serviceRepo repository.ServiceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	healthRepo repository.HealthCheckRepository,
+	localeResolver *i18n.Resolver,
+	store cache.Store,
+) StatusPageService {
+	return &statusPageService{
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		healthRepo:       healthRepo,
+		localeResolver:   localeResolver,
+		cache:            cache.New(store, "status-page", statusPageTTL),
+	}
+}
+
+func (s *statusPageService) GetStatusPage(ctx context.Context, req request.StatusPageRequest) (*response.StatusPageResponse, error) {
+	locales := s.localeResolver.Chain(req.Locales...)
+	key := req.OrganizationID + "|" + strings.Join(locales, ",")
+
+	return cache.Fetch(ctx, s.cache, key, func(ctx context.Context) (*response.StatusPageResponse, error) {
+		// organization không có service nào thì không dựng trang, lỗi không được cache
+		exists, err := s.serviceRepo.HasOrganization(ctx, req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrOrganizationNotFound
+		}
+		return s.build(ctx, req.OrganizationID, locales, time.Now())
+	})
+}
+
+// build chỉ lấy service active không giới hạn role của organization (kèm service dùng chung) vì status page là công khai
+func (s *statusPageService) build(ctx context.Context, organizationID string, locales []string, now time.Time) (*response.StatusPageResponse, error) {
+	services, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{
+		OrganizationID: organizationID,
+		Status:         string(constants.ServiceStatusActive),
+		Roles:          []string{},
+	}, repository.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.serviceGroupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.summarize(ctx, services, now)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*model.Service, len(services))
+	for _, svc := range services {
+		byID[svc.ID.Hex()] = svc
+	}
+
+	page := &response.StatusPageResponse{GeneratedAt: now, Groups: []response.StatusGroupResDto{}}
+	up, down := 0, 0
+	for _, item := range mapper.MapServicesResponse(groups, services, locales...) {
+		if len(item.Services) == 0 {
+			continue
+		}
+
+		group := response.StatusGroupResDto{Group: item.Group}
+		for _, dto := range item.Services {
+			svc := byID[dto.ID]
+			status := serviceStatus(svc, dto, summaries)
+			group.Services = append(group.Services, status)
+
+			switch status.Status {
+			case model.HealthStatusUp:
+				up++
+			case model.HealthStatusDown:
+				down++
+			}
+		}
+		page.Groups = append(page.Groups, group)
+	}
+
+	switch {
+	case down == 0:
+		page.Status = StatusOperational
+	case up == 0:
+		page.Status = StatusOutage
+	default:
+		page.Status = StatusDegraded
+	}
+	return page, nil
+}
+
+func (s *statusPageService) summarize(ctx context.Context, services []*model.Service, now time.Time) (map[string]health.Summary, error) {
+	ids := make([]string, 0, len(services))
+	for _, svc := range services {
+		ids = append(ids, svc.ID.Hex())
+	}
+
+	summaries := make(map[string]health.Summary, len(ids))
+	since := now.Add(-health.Windows[len(health.Windows)-1])
+
+	// lịch sử đã sắp theo service_id nên chỉ cần giữ summarizer của service hiện tại
+	var currentID string
+	var current *health.Summarizer
+	err := s.healthRepo.Scan(ctx, ids, since, func(check *model.HealthCheck) error {
+		if check.ServiceID != currentID {
+			if current != nil {
+				summaries[currentID] = current.Summary()
+			}
+			currentID, current = check.ServiceID, health.NewSummarizer(now)
+		}
+		current.Add(check)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		summaries[currentID] = current.Summary()
+	}
+	return summaries, nil
+}
+
+func serviceStatus(svc *model.Service, dto response.ServiceResDto, summaries map[string]health.Summary) response.ServiceStatusResDto {
+	status := response.ServiceStatusResDto{
+		ID:        dto.ID,
+		Title:     dto.Title,
+		Status:    model.HealthStatusUnknown,
+		Incidents: []response.IncidentResDto{},
+	}
+	if svc != nil && svc.Health != nil {
+		status.Status = svc.Health.Status
+		status.LastChecked = &svc.Health.LastChecked
+	}
+
+	summary, ok := summaries[dto.ID]
+	if !ok {
+		return status
+	}
+	status.Uptime = response.UptimeResDto{
+		Last24h: summary.Uptime[0],
+		Last7d:  summary.Uptime[1],
+		Last30d: summary.Uptime[2],
+	}
+	status.ResponseTime = response.ResponseTimeResDto{P50: summary.P50, P95: summary.P95, P99: summary.P99}
+	for _, incident := range summary.Incidents {
+		end := time.Now()
+		if incident.End != nil {
+			end = *incident.End
+		}
+		status.Incidents = append(status.Incidents, response.IncidentResDto{
+			Start:           incident.Start,
+			End:             incident.End,
+			DurationSeconds: int64(end.Sub(incident.Start).Seconds()),
+		})
+	}
+	return status
+}
@@ -2,33 +2,232 @@ package service
 
 import (
 	"context"
+	"errors"
 	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/events"
 	"services-management/internal/sv_management/model"
 	"services-management/internal/sv_management/repository"
+	"services-management/pkg/i18n"
+	"slices"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type SVGroupService interface {
 	UploadServiceGroup(ctx context.Context, req request.UploadServiceGroupRequest) error
+	MoveServiceGroup(ctx context.Context, id string, req request.MoveServiceGroupRequest) error
+	ReorderServiceGroups(ctx context.Context, req request.ReorderServiceGroupsRequest) error
+	// DeleteServiceGroup chỉ xoá được group không còn group con và service
+	DeleteServiceGroup(ctx context.Context, id string) error
 }
 
+// groupTreeLease tạo và chuyển group phải tuần tự giữa các instance, không thì hai request
+// chuyển A vào B và B vào A cùng lúc đều qua được kiểm tra path và lưu thành vòng
+const groupTreeLease = "service-group-tree"
+
 type svGroupService struct {
-	repository repository.ServiceGroupRepository
+	repository     repository.ServiceGroupRepository
+	serviceRepo    repository.ServiceRepository
+	leaseRepo      repository.LeaseRepository
+	assetService   AssetService
+	localeResolver *i18n.Resolver
+	recorder       events.Recorder
 }
 
-func NewSVGroupService(repository repository.ServiceGroupRepository) SVGroupService {
+func NewSVGroupService(
+	repository repository.ServiceGroupRepository,
+	serviceRepo repository.ServiceRepository,
+	leaseRepo repository.LeaseRepository,
+	assetService AssetService,
+	localeResolver *i18n.Resolver,
+	recorder events.Recorder,
+) SVGroupService {
 	return &svGroupService{
-		repository: repository,
+		repository:     repository,
+		serviceRepo:    serviceRepo,
+		leaseRepo:      leaseRepo,
+		assetService:   assetService,
+		localeResolver: localeResolver,
+		recorder:       recorder,
 	}
 }
 
 func (s *svGroupService) UploadServiceGroup(ctx context.Context, req request.UploadServiceGroupRequest) error {
+	icon, err := s.assetService.ResolveIcon(ctx, req.Icon)
+	if err != nil {
+		return err
+	}
+	translations, err := buildTranslations(s.localeResolver, req.Translations)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := holdLease(ctx, s.leaseRepo, groupTreeLease, ErrGroupTreeLocked)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	path, err := s.pathUnder(ctx, req.ParentID, req.OrganizationID)
+	if err != nil {
+		return err
+	}
 
 	serviceGroup := &model.ServiceGroup{
-		ID:    primitive.NewObjectID(),
-		Title: req.Title,
-		Order: req.Order,
+		ID:             primitive.NewObjectID(),
+		Title:          req.Title,
+		Order:          req.Order,
+		OrganizationID: req.OrganizationID,
+		ParentID:       req.ParentID,
+		Path:           path,
+		Icon:           icon,
+		Translations:   translations,
+	}
+	return s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.repository.Upload(ctx, serviceGroup); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.GroupEvent(events.GroupCreated, serviceGroup)}, nil
+	})
+}
+
+// MoveServiceGroup chuyển group (kèm cây con) sang group cha khác, ParentID rỗng là lên gốc
+func (s *svGroupService) MoveServiceGroup(ctx context.Context, id string, req request.MoveServiceGroupRequest) error {
+	unlock, err := holdLease(ctx, s.leaseRepo, groupTreeLease, ErrGroupTreeLocked)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// đọc group và path của group cha sau khi giữ lease để kiểm tra vòng trên dữ liệu mới nhất
+	group, err := s.getGroup(ctx, id, ErrServiceGroupNotFound)
+	if err != nil {
+		return err
+	}
+
+	path, err := s.pathUnder(ctx, req.ParentID, group.OrganizationID)
+	if err != nil {
+		return err
+	}
+	// Không được chuyển vào chính nó hoặc cây con của nó
+	if slices.Contains(path, id) || req.ParentID == id {
+		return ErrGroupCycle
+	}
+
+	return s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.repository.Move(ctx, group, req.ParentID, path, req.Order); err != nil {
+			return nil, err
+		}
+		moved := *group
+		moved.ParentID, moved.Path, moved.Order, moved.UpdatedAt = req.ParentID, path, req.Order, time.Now()
+		return []events.Event{events.GroupEvent(events.GroupUpdated, &moved)}, nil
+	})
+}
+
+func (s *svGroupService) ReorderServiceGroups(ctx context.Context, req request.ReorderServiceGroupsRequest) error {
+	groups, _, err := s.repository.Find(ctx, repository.ServiceGroupFilter{ParentID: &req.ParentID}, repository.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	// group_ids phải đúng bằng tập group con hiện tại
+	children := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		children[g.ID.Hex()] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(req.GroupIDs))
+	for _, id := range req.GroupIDs {
+		if _, ok := children[id]; !ok {
+			return ErrInvalidReorder
+		}
+		seen[id] = struct{}{}
+	}
+	if len(seen) != len(children) || len(req.GroupIDs) != len(children) {
+		return ErrInvalidReorder
+	}
+
+	return s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.repository.UpdateOrders(ctx, req.GroupIDs); err != nil {
+			return nil, err
+		}
+		// aggregate là group cha, các group gốc dùng chung aggregate "root"
+		aggregateID := req.ParentID
+		if aggregateID == "" {
+			aggregateID = "root"
+		}
+		return []events.Event{events.New(events.GroupReordered, aggregateID, events.ReorderData{
+			OrganizationID: commonOrganization(groups),
+			ParentID:       req.ParentID,
+			GroupIDs:       req.GroupIDs,
+		})}, nil
+	})
+}
+
+func (s *svGroupService) DeleteServiceGroup(ctx context.Context, id string) error {
+	group, err := s.getGroup(ctx, id, ErrServiceGroupNotFound)
+	if err != nil {
+		return err
+	}
+
+	_, children, err := s.repository.Find(ctx, repository.ServiceGroupFilter{ParentID: &id}, repository.ListOptions{Size: 1})
+	if err != nil {
+		return err
+	}
+	_, services, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{GroupIDs: []string{id}}, repository.ListOptions{Size: 1})
+	if err != nil {
+		return err
+	}
+	if children > 0 || services > 0 {
+		return ErrServiceGroupNotEmpty
+	}
+
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.repository.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.GroupEvent(events.GroupDeleted, group)}, nil
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrServiceGroupNotFound
+	}
+	return err
+}
+
+// commonOrganization organization chung của các group, rỗng nếu group thuộc nhiều organization
+func commonOrganization(groups []*model.ServiceGroup) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	for _, g := range groups[1:] {
+		if g.OrganizationID != groups[0].OrganizationID {
+			return ""
+		}
+	}
+	return groups[0].OrganizationID
+}
+
+// pathUnder trả về path cho group con của parentID, group cha phải cùng organization với group con
+func (s *svGroupService) pathUnder(ctx context.Context, parentID, organizationID string) ([]string, error) {
+	if parentID == "" {
+		return []string{}, nil
+	}
+
+	parent, err := s.getGroup(ctx, parentID, ErrParentGroupNotFound)
+	if err != nil {
+		return nil, err
+	}
+	if parent.OrganizationID != organizationID {
+		return nil, ErrParentGroupOrganization
+	}
+	return append(append([]string{}, parent.Path...), parent.ID.Hex()), nil
+}
+
+func (s *svGroupService) getGroup(ctx context.Context, id string, notFound error) (*model.ServiceGroup, error) {
+	group, err := s.repository.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return nil, notFound
 	}
-	return s.repository.Upload(ctx, serviceGroup)
+	return group, err
 }
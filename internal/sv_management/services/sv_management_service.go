@@ -2,59 +2,557 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"services-management/internal/sv_management/cache"
 	"services-management/internal/sv_management/dto/request"
 	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
 	"services-management/internal/sv_management/mapper"
 	"services-management/internal/sv_management/model"
 	"services-management/internal/sv_management/repository"
+	"services-management/internal/sv_management/search"
+	"services-management/logger"
+	"services-management/pkg/constants"
+	"services-management/pkg/i18n"
+	"services-management/pkg/urltemplate"
+	"slices"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type SvManagementService interface {
 	UploadService(ctx context.Context, req request.UploadServiceRequest) error
-	GetServices(ctx context.Context) ([]*response.ServicesResponse, error)
+	GetServices(ctx context.Context, req request.GetServicesRequest) (*response.ServicesPageResponse, error)
+	// GetAllServices mọi group kèm services, không phân trang (dạng trả về cũ của GET /services)
+	GetAllServices(ctx context.Context, req request.GetServicesRequest) ([]*response.ServicesResponse, error)
+	GetServicesTree(ctx context.Context, req request.GetServicesRequest) (*response.ServicesTreePageResponse, error)
+	ListServices(ctx context.Context, req request.GetServicesRequest) (*response.ServiceListResponse, error)
+	DeleteService(ctx context.Context, id string) error
+	// ListTags tag đang được dùng kèm số service
+	ListTags(ctx context.Context, req request.ListTagsRequest) ([]*response.TagCountResDto, error)
+	SetServiceTags(ctx context.Context, id string, req request.SetServiceTagsRequest) error
+	// RenameTag đổi tên tag trên mọi service và smart collection, tên mới đã có thì gộp lại
+	RenameTag(ctx context.Context, tag string, req request.RenameTagRequest) error
+	// DeleteTag bỏ tag khỏi mọi service và smart collection
+	DeleteTag(ctx context.Context, tag string) error
+	// CatalogCacheStats thống kê cache của GetServices/GetServicesTree
+	CatalogCacheStats() cache.Stats
+	// InvalidateCatalogCache xoá cache catalog, dùng khi dữ liệu đổi ngoài luồng ghi thông thường
+	InvalidateCatalogCache(ctx context.Context)
 }
 
 type svManagementService struct {
 	serviceRepo      repository.ServiceRepository
 	serviceGroupRepo repository.ServiceGroupRepository
+	collectionRepo   repository.SmartCollectionRepository
+	searchEngine     search.Engine
+	assetService     AssetService
+	localeResolver   *i18n.Resolver
+	requiredEnvs     []string
+	recorder         events.Recorder
+	catalogCache     *cache.Cache
 }
 
 func NewSvManagementService(
 	serviceRepo repository.ServiceRepository,
 	serviceGroupRepo repository.ServiceGroupRepository,
+	collectionRepo repository.SmartCollectionRepository,
+	searchEngine search.Engine,
+	assetService AssetService,
+	localeResolver *i18n.Resolver,
+	requiredEnvs []string,
+	recorder events.Recorder,
+	catalogCache *cache.Cache,
 ) *svManagementService {
 	return &svManagementService{
 		serviceRepo:      serviceRepo,
 		serviceGroupRepo: serviceGroupRepo,
+		collectionRepo:   collectionRepo,
+		searchEngine:     searchEngine,
+		assetService:     assetService,
+		localeResolver:   localeResolver,
+		requiredEnvs:     requiredEnvs,
+		recorder:         recorder,
+		catalogCache:     catalogCache,
 	}
 }
 
 func (s *svManagementService) UploadService(ctx context.Context, req request.UploadServiceRequest) error {
+	urls, err := s.buildUrls(req.Url, req.Urls)
+	if err != nil {
+		return err
+	}
+	status := req.Status
+	if status == "" {
+		status = string(constants.ServiceStatusActive)
+	}
+	launchMode := req.LaunchMode
+	if launchMode == "" {
+		launchMode = string(constants.LaunchModeNewTab)
+	}
+	icon, err := s.assetService.ResolveIcon(ctx, req.Icon)
+	if err != nil {
+		return err
+	}
+	translations, err := buildTranslations(s.localeResolver, req.Translations)
+	if err != nil {
+		return err
+	}
 
 	service := &model.Service{
-		ID:      primitive.NewObjectID(),
-		Title:   req.Title,
-		Url:     req.Url,
-		Order:   req.Order,
-		GroupID: req.GroupID,
+		ID:             primitive.NewObjectID(),
+		Title:          req.Title,
+		Url:            req.Url,
+		Urls:           urls,
+		Order:          req.Order,
+		GroupID:        req.GroupID,
+		OrganizationID: req.OrganizationID,
+		Description:    strings.TrimSpace(req.Description),
+		Icon:           icon,
+		Color:          strings.ToUpper(req.Color),
+		LaunchMode:     launchMode,
+		Tags:           normalizeTags(req.Tags),
+		Status:         status,
+		Roles:          req.Roles,
+		Translations:   translations,
+		Ownership:      mapper.MapOwnershipRequest(req.Ownership),
+	}
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.serviceRepo.Upload(ctx, service); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.ServiceEvent(events.ServiceCreated, service)}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.indexService(ctx, service)
+	return nil
+}
+
+func (s *svManagementService) DeleteService(ctx context.Context, id string) error {
+	service, err := s.serviceRepo.GetByID(ctx, id)
+	if err != nil {
+		return mapServiceNotFound(err)
+	}
+	// service khác đang phụ thuộc vào service bị xoá thì bỏ cạnh đó đi
+	dependents, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{DependsOn: id}, repository.ListOptions{})
+	if err != nil {
+		return err
+	}
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.serviceRepo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		changes := []events.Event{events.ServiceEvent(events.ServiceDeleted, service)}
+		for _, dependent := range dependents {
+			dependent.DependsOn = slices.DeleteFunc(slices.Clone(dependent.DependsOn), func(dep string) bool { return dep == id })
+			dependent.UpdatedAt = time.Now()
+			if err := s.serviceRepo.SetDependencies(ctx, dependent.ID.Hex(), dependent.DependsOn); err != nil {
+				return nil, err
+			}
+			changes = append(changes, events.ServiceEvent(events.ServiceUpdated, dependent))
+		}
+		return changes, nil
+	})
+	if err != nil {
+		return mapServiceNotFound(err)
+	}
+
+	if err := s.searchEngine.Delete(ctx, id); err != nil {
+		logger.WriteLogEx("warn", "remove service from search index failed", map[string]any{
+			"service_id": id,
+			"error":      err.Error(),
+		})
+	}
+	return nil
+}
+
+func (s *svManagementService) ListTags(ctx context.Context, req request.ListTagsRequest) ([]*response.TagCountResDto, error) {
+	counts, err := s.serviceRepo.TagCounts(ctx, repository.ServiceFilter{OrganizationID: req.OrganizationID})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*response.TagCountResDto, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, &response.TagCountResDto{Tag: c.Tag, Count: c.Count})
+	}
+	return result, nil
+}
+
+func (s *svManagementService) SetServiceTags(ctx context.Context, id string, req request.SetServiceTagsRequest) error {
+	service, err := s.serviceRepo.GetByID(ctx, id)
+	if err != nil {
+		return mapServiceNotFound(err)
+	}
+	service.Tags = normalizeTags(req.Tags)
+	service.UpdatedAt = time.Now()
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := s.serviceRepo.SetTags(ctx, id, service.Tags); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.ServiceEvent(events.ServiceUpdated, service)}, nil
+	})
+	if err != nil {
+		return mapServiceNotFound(err)
+	}
+
+	s.indexService(ctx, service)
+	return nil
+}
+
+func (s *svManagementService) RenameTag(ctx context.Context, tag string, req request.RenameTagRequest) error {
+	from := strings.ToLower(strings.TrimSpace(tag))
+	names := normalizeTags([]string{req.Name})
+	if from == "" || len(names) == 0 {
+		return ErrInvalidTag
+	}
+	to := names[0]
+	return s.replaceTag(ctx, from, func(tags []string) []string {
+		renamed := make([]string, 0, len(tags))
+		for _, t := range tags {
+			if t == from {
+				t = to
+			}
+			renamed = append(renamed, t)
+		}
+		return normalizeTags(renamed)
+	})
+}
+
+func (s *svManagementService) DeleteTag(ctx context.Context, tag string) error {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return ErrInvalidTag
+	}
+	return s.replaceTag(ctx, tag, func(tags []string) []string {
+		return slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag })
+	})
+}
+
+// replaceTag sửa tags của mọi service và collection đang dùng tag trong cùng một lần ghi,
+// mỗi service/collection bị sửa sinh một event updated
+func (s *svManagementService) replaceTag(ctx context.Context, tag string, replace func(tags []string) []string) error {
+	services, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{Tag: tag}, repository.ListOptions{})
+	if err != nil {
+		return err
+	}
+	collections, err := s.collectionRepo.Find(ctx, repository.SmartCollectionFilter{Tag: tag})
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 && len(collections) == 0 {
+		return ErrTagNotFound
+	}
+
+	err = s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		changes := make([]events.Event, 0, len(services)+len(collections))
+		for _, svc := range services {
+			svc.Tags = replace(svc.Tags)
+			svc.UpdatedAt = time.Now()
+			if err := s.serviceRepo.SetTags(ctx, svc.ID.Hex(), svc.Tags); err != nil {
+				return nil, err
+			}
+			changes = append(changes, events.ServiceEvent(events.ServiceUpdated, svc))
+		}
+		for _, c := range collections {
+			c.Query.Tags = replace(c.Query.Tags)
+			if err := s.collectionRepo.Update(ctx, c); err != nil {
+				return nil, err
+			}
+			changes = append(changes, events.CollectionEvent(events.CollectionUpdated, c))
+		}
+		return changes, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range services {
+		s.indexService(ctx, svc)
+	}
+	return nil
+}
+
+// indexService cập nhật search index, lỗi chỉ ghi log vì Mongo vẫn là nguồn dữ liệu chính
+func (s *svManagementService) indexService(ctx context.Context, service *model.Service) {
+	groupTitle := ""
+	if group, err := s.serviceGroupRepo.GetByID(ctx, service.GroupID); err == nil {
+		groupTitle = group.Title
+	}
+
+	if err := s.searchEngine.Index(ctx, search.NewDocument(service, groupTitle)); err != nil {
+		logger.WriteLogEx("warn", "index service failed", map[string]any{
+			"service_id": service.ID.Hex(),
+			"error":      err.Error(),
+		})
 	}
-	return s.serviceRepo.Upload(ctx, service)
 }
 
-func (s *svManagementService) GetServices(ctx context.Context) ([]*response.ServicesResponse, error) {
+func (s *svManagementService) CatalogCacheStats() cache.Stats {
+	return s.catalogCache.Stats()
+}
+
+func (s *svManagementService) InvalidateCatalogCache(ctx context.Context) {
+	s.catalogCache.Invalidate(ctx)
+}
+
+// catalogCacheKey key theo org/role/locale, các điều kiện lọc và phân trang còn lại được băm cho gọn
+func (s *svManagementService) catalogCacheKey(shape string, req request.GetServicesRequest) string {
+	filters := fmt.Sprintf("%d|%d|%s|%s|%s|%s|%s|%s", req.Page, req.Size, req.Search, req.GroupID, req.Tag, req.Status, req.SortBy, req.SortOrder)
+	sum := sha256.Sum256([]byte(filters))
+	locales := strings.Join(s.localeResolver.Chain(req.Locales...), ",")
+	return strings.Join([]string{shape, req.OrganizationID, req.Role, locales, hex.EncodeToString(sum[:8])}, ":")
+}
+
+// GetServices trả về danh sách group (có phân trang) kèm services đã lọc của từng group, đọc qua cache
+func (s *svManagementService) GetServices(ctx context.Context, req request.GetServicesRequest) (*response.ServicesPageResponse, error) {
+	return cache.Fetch(ctx, s.catalogCache, s.catalogCacheKey("flat", req), func(ctx context.Context) (*response.ServicesPageResponse, error) {
+		return s.loadServices(ctx, req, buildListOptions(req))
+	})
+}
+
+func (s *svManagementService) GetAllServices(ctx context.Context, req request.GetServicesRequest) ([]*response.ServicesResponse, error) {
+	return cache.Fetch(ctx, s.catalogCache, s.catalogCacheKey("all", req), func(ctx context.Context) ([]*response.ServicesResponse, error) {
+		// Size = 0 là lấy tất cả group
+		page, err := s.loadServices(ctx, req, repository.ListOptions{SortBy: req.SortBy, SortDesc: req.SortOrder == "desc"})
+		if err != nil {
+			return nil, err
+		}
+		return page.Items, nil
+	})
+}
+
+func (s *svManagementService) loadServices(ctx context.Context, req request.GetServicesRequest, opts repository.ListOptions) (*response.ServicesPageResponse, error) {
+	serviceFilter := buildServiceFilter(req)
+
+	groupFilter := repository.ServiceGroupFilter{}
+	if req.GroupID != "" {
+		groupFilter.IDs = []string{req.GroupID}
+	}
+
+	// Có lọc theo service thì chỉ giữ các group chứa service khớp điều kiện
+	if hasServiceFilter(req) {
+		groupIDs, err := s.serviceRepo.DistinctGroupIDs(ctx, serviceFilter)
+		if err != nil {
+			return nil, err
+		}
+		groupFilter.IDs = intersectIDs(groupFilter.IDs, groupIDs)
+	}
+
 	// Lấy groups
+	groups, totalGroups, err := s.serviceGroupRepo.Find(ctx, groupFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lấy services của các group trong trang hiện tại
+	serviceFilter.GroupIDs = make([]string, 0, len(groups))
+	for _, g := range groups {
+		serviceFilter.GroupIDs = append(serviceFilter.GroupIDs, g.ID.Hex())
+	}
+
+	var services []*model.Service
+	var totalServices int64
+	if len(serviceFilter.GroupIDs) > 0 {
+		services, totalServices, err = s.serviceRepo.Find(ctx, serviceFilter, repository.ListOptions{
+			SortBy:   opts.SortBy,
+			SortDesc: opts.SortDesc,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response.ServicesPageResponse{
+		Items:         mapper.MapServicesResponse(groups, services, s.localeResolver.Chain(req.Locales...)...),
+		TotalServices: totalServices,
+		Pagination: response.PaginationResponse{
+			Page:  opts.Page,
+			Size:  opts.Size,
+			Total: totalGroups,
+		},
+	}, nil
+}
+
+// GetServicesTree trả về cây group (phân trang theo group gốc), group_id thì lấy cây con của group đó
+func (s *svManagementService) GetServicesTree(ctx context.Context, req request.GetServicesRequest) (*response.ServicesTreePageResponse, error) {
+	return cache.Fetch(ctx, s.catalogCache, s.catalogCacheKey("tree", req), func(ctx context.Context) (*response.ServicesTreePageResponse, error) {
+		return s.loadServicesTree(ctx, req)
+	})
+}
+
+func (s *svManagementService) loadServicesTree(ctx context.Context, req request.GetServicesRequest) (*response.ServicesTreePageResponse, error) {
+	opts := buildListOptions(req)
+
 	groups, err := s.serviceGroupRepo.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
+	services, totalServices, err := s.serviceRepo.Find(ctx, buildServiceFilter(req), repository.ListOptions{
+		SortBy:   opts.SortBy,
+		SortDesc: opts.SortDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// Lấy services
-	services, err := s.serviceRepo.GetAll(ctx)
+	roots := mapper.MapServicesTreeResponse(groups, services, hasServiceFilter(req), s.localeResolver.Chain(req.Locales...)...)
+	if req.GroupID != "" {
+		node := mapper.FindTreeNode(roots, req.GroupID)
+		roots = nil
+		if node != nil {
+			roots = append(roots, node)
+		}
+	}
+
+	total := int64(len(roots))
+	start := min((opts.Page-1)*opts.Size, len(roots))
+	end := min(start+opts.Size, len(roots))
+
+	return &response.ServicesTreePageResponse{
+		Items:         roots[start:end],
+		TotalServices: totalServices,
+		Pagination: response.PaginationResponse{
+			Page:  opts.Page,
+			Size:  opts.Size,
+			Total: total,
+		},
+	}, nil
+}
+
+// ListServices trả về danh sách services dạng phẳng (có phân trang)
+func (s *svManagementService) ListServices(ctx context.Context, req request.GetServicesRequest) (*response.ServiceListResponse, error) {
+	opts := buildListOptions(req)
+	filter := buildServiceFilter(req)
+	if req.GroupID != "" {
+		filter.GroupIDs = []string{req.GroupID}
+	}
+
+	services, total, err := s.serviceRepo.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return mapper.MapServicesResponse(groups, services), nil
+	return &response.ServiceListResponse{
+		Items: mapper.MapServicesToServiceResDtos(services, s.localeResolver.Chain(req.Locales...)...),
+		Pagination: response.PaginationResponse{
+			Page:  opts.Page,
+			Size:  opts.Size,
+			Total: total,
+		},
+	}, nil
+}
+
+func buildListOptions(req request.GetServicesRequest) repository.ListOptions {
+	opts := pageOptions(req.Page, req.Size)
+	opts.SortBy = req.SortBy
+	opts.SortDesc = req.SortOrder == "desc"
+	return opts
+}
+
+// pageOptions chuẩn hoá page/size theo giới hạn chung
+func pageOptions(page, size int) repository.ListOptions {
+	if page < 1 {
+		page = constants.DefaultPage
+	}
+	if size < 1 {
+		size = constants.DefaultSize
+	}
+	if size > constants.MaxSize {
+		size = constants.MaxSize
+	}
+	return repository.ListOptions{Page: page, Size: size}
+}
+
+func buildServiceFilter(req request.GetServicesRequest) repository.ServiceFilter {
+	filter := repository.ServiceFilter{
+		OrganizationID: req.OrganizationID,
+		Tag:            strings.ToLower(strings.TrimSpace(req.Tag)),
+		Status:         req.Status,
+		Search:         strings.TrimSpace(req.Search),
+	}
+	if req.Role != "" {
+		filter.Roles = []string{req.Role}
+	}
+	return filter
+}
+
+// buildTranslations chỉ nhận các locale hợp lệ và được hỗ trợ
+func buildTranslations(resolver *i18n.Resolver, reqs map[string]request.TranslationRequest) (map[string]model.Translation, error) {
+	for locale := range reqs {
+		if !i18n.IsValid(locale) || !resolver.IsSupported(locale) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+		}
+	}
+	return mapper.MapTranslationRequests(reqs), nil
+}
+
+// buildUrls kiểm tra url mặc định và url theo môi trường, môi trường bắt buộc phải có url riêng
+func (s *svManagementService) buildUrls(defaultUrl string, urls map[string]string) (map[string]string, error) {
+	if err := urltemplate.Validate(defaultUrl); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(urls))
+	for env, url := range urls {
+		if err := urltemplate.Validate(url); err != nil {
+			return nil, fmt.Errorf("%w (environment %s)", err, env)
+		}
+		result[strings.ToLower(strings.TrimSpace(env))] = url
+	}
+	for _, env := range s.requiredEnvs {
+		if result[env] == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingEnvironmentUrl, env)
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// normalizeTags trim, lowercase và bỏ tag trùng
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	return result
+}
+
+func hasServiceFilter(req request.GetServicesRequest) bool {
+	return req.OrganizationID != "" || req.Tag != "" || req.Status != "" || req.Role != "" || strings.TrimSpace(req.Search) != ""
+}
+
+// intersectIDs giao 2 danh sách id, base = nil nghĩa là không giới hạn
+func intersectIDs(base, ids []string) []string {
+	if base == nil {
+		return ids
+	}
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	result := make([]string, 0, len(base))
+	for _, id := range base {
+		if _, ok := set[id]; ok {
+			result = append(result, id)
+		}
+	}
+	return result
 }
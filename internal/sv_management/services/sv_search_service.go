@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/gateway"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/internal/sv_management/search"
+	"services-management/pkg/constants"
+	"slices"
+	"strings"
+)
+
+type SvSearchService interface {
+	Search(ctx context.Context, req request.SearchServicesRequest) (*response.SearchServicesResponse, error)
+	// SearchCatalog chỉ trả service user được thấy: active, đúng organization đang dùng và role của user
+	SearchCatalog(ctx context.Context, req request.SearchCatalogRequest) (*response.SearchServicesResponse, error)
+	Reindex(ctx context.Context) error
+}
+
+type svSearchService struct {
+	engine           search.Engine
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	userGateway      gateway.UserGateway
+}
+
+func NewSvSearchService(
+	engine search.Engine,
+	serviceRepo repository.ServiceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	userGateway gateway.UserGateway,
+) SvSearchService {
+	return &svSearchService{
+		engine:           engine,
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		userGateway:      userGateway,
+	}
+}
+
+func (s *svSearchService) Search(ctx context.Context, req request.SearchServicesRequest) (*response.SearchServicesResponse, error) {
+	query := search.Query{
+		Text:           strings.TrimSpace(req.Query),
+		OrganizationID: req.OrganizationID,
+		Status:         req.Status,
+		Size:           searchSize(req.Size),
+	}
+	if req.Role != "" {
+		query.Roles = []string{req.Role}
+	}
+	return s.search(ctx, query, nil)
+}
+
+func (s *svSearchService) SearchCatalog(ctx context.Context, req request.SearchCatalogRequest) (*response.SearchServicesResponse, error) {
+	user, err := currentUser(ctx, s.userGateway)
+	if err != nil {
+		return nil, err
+	}
+	organizationID := user.OrganizationIdActive
+	roles := rolesFromContext(ctx)
+
+	// organization rỗng ở engine là mọi organization nên lọc lại như visibleServices
+	return s.search(ctx, search.Query{
+		Text:           strings.TrimSpace(req.Query),
+		OrganizationID: organizationID,
+		Roles:          roles,
+		Status:         string(constants.ServiceStatusActive),
+		Size:           searchSize(req.Size),
+	}, func(svc *model.Service) bool {
+		return isVisible(svc, organizationID, roles)
+	})
+}
+
+// search visible nil là không lọc thêm sau engine
+func (s *svSearchService) search(ctx context.Context, query search.Query, visible func(*model.Service) bool) (*response.SearchServicesResponse, error) {
+	hits, err := s.engine.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	services, err := s.serviceRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if visible != nil {
+		services = slices.DeleteFunc(services, func(svc *model.Service) bool { return !visible(svc) })
+	}
+	groupTitles, err := s.groupTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapSearchResponse(query.Text, hits, services, groupTitles), nil
+}
+
+// Reindex đẩy toàn bộ services sang search engine (dùng khi bật Elasticsearch)
+func (s *svSearchService) Reindex(ctx context.Context) error {
+	services, err := s.serviceRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	groupTitles, err := s.groupTitles(ctx)
+	if err != nil {
+		return err
+	}
+
+	docs := make([]search.Document, 0, len(services))
+	for _, svc := range services {
+		docs = append(docs, search.NewDocument(svc, groupTitles[svc.GroupID]))
+	}
+	return s.engine.Reindex(ctx, docs)
+}
+
+func searchSize(size int) int {
+	if size < 1 {
+		return constants.DefaultSize
+	}
+	return min(size, constants.MaxSize)
+}
+
+func (s *svSearchService) groupTitles(ctx context.Context) (map[string]string, error) {
+	groups, err := s.serviceGroupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[string]string, len(groups))
+	for _, g := range groups {
+		titles[g.ID.Hex()] = g.Title
+	}
+	return titles, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/pkg/i18n"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TranslationService interface {
+	SetServiceTranslation(ctx context.Context, id, locale string, req request.TranslationRequest) error
+	DeleteServiceTranslation(ctx context.Context, id, locale string) error
+	SetGroupTranslation(ctx context.Context, id, locale string, req request.TranslationRequest) error
+	DeleteGroupTranslation(ctx context.Context, id, locale string) error
+	GetMissing(ctx context.Context, locale string) (*response.MissingTranslationsResponse, error)
+}
+
+type translationService struct {
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	localeResolver   *i18n.Resolver
+	recorder         events.Recorder
+}
+
+func NewTranslationService(
+	serviceRepo repository.ServiceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	localeResolver *i18n.Resolver,
+	recorder events.Recorder,
+) TranslationService {
+	return &translationService{
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		localeResolver:   localeResolver,
+		recorder:         recorder,
+	}
+}
+
+func (s *translationService) SetServiceTranslation(ctx context.Context, id, locale string, req request.TranslationRequest) error {
+	locale, err := s.checkLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return s.updateService(ctx, id, func(ctx context.Context) error {
+		return s.serviceRepo.SetTranslation(ctx, id, locale, model.Translation{
+			Title:       strings.TrimSpace(req.Title),
+			Description: strings.TrimSpace(req.Description),
+		})
+	})
+}
+
+func (s *translationService) DeleteServiceTranslation(ctx context.Context, id, locale string) error {
+	locale, err := s.checkLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return s.updateService(ctx, id, func(ctx context.Context) error {
+		return s.serviceRepo.DeleteTranslation(ctx, id, locale)
+	})
+}
+
+func (s *translationService) SetGroupTranslation(ctx context.Context, id, locale string, req request.TranslationRequest) error {
+	locale, err := s.checkLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return s.updateGroup(ctx, id, func(ctx context.Context) error {
+		return s.serviceGroupRepo.SetTranslation(ctx, id, locale, model.Translation{
+			Title: strings.TrimSpace(req.Title),
+		})
+	})
+}
+
+func (s *translationService) DeleteGroupTranslation(ctx context.Context, id, locale string) error {
+	locale, err := s.checkLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return s.updateGroup(ctx, id, func(ctx context.Context) error {
+		return s.serviceGroupRepo.DeleteTranslation(ctx, id, locale)
+	})
+}
+
+// GetMissing liệt kê services/groups thiếu bản dịch, locale rỗng thì kiểm tra mọi locale được hỗ trợ
+func (s *translationService) GetMissing(ctx context.Context, locale string) (*response.MissingTranslationsResponse, error) {
+	var locales []string
+	if locale != "" {
+		l, err := s.checkLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		locales = []string{l}
+	} else {
+		// Title/Description gốc đã là locale mặc định
+		for _, l := range s.localeResolver.Supported() {
+			if l != s.localeResolver.DefaultLocale() {
+				locales = append(locales, l)
+			}
+		}
+	}
+
+	groups, err := s.serviceGroupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.serviceRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*response.MissingTranslationResDto, 0)
+	for _, l := range locales {
+		for _, g := range groups {
+			if g.Translations[l].Title == "" {
+				items = append(items, &response.MissingTranslationResDto{
+					Type:   "group",
+					ID:     g.ID.Hex(),
+					Title:  g.Title,
+					Locale: l,
+					Fields: []string{"title"},
+				})
+			}
+		}
+		for _, svc := range services {
+			t := svc.Translations[l]
+			var fields []string
+			if t.Title == "" {
+				fields = append(fields, "title")
+			}
+			if svc.Description != "" && t.Description == "" {
+				fields = append(fields, "description")
+			}
+			if len(fields) > 0 {
+				items = append(items, &response.MissingTranslationResDto{
+					Type:   "service",
+					ID:     svc.ID.Hex(),
+					Title:  svc.Title,
+					Locale: l,
+					Fields: fields,
+				})
+			}
+		}
+	}
+
+	return &response.MissingTranslationsResponse{
+		Locales: locales,
+		Items:   items,
+		Total:   len(items),
+	}, nil
+}
+
+// updateService chạy write rồi ghi event service.updated kèm snapshot mới trong cùng transaction
+func (s *translationService) updateService(ctx context.Context, id string, write func(ctx context.Context) error) error {
+	err := s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := write(ctx); err != nil {
+			return nil, err
+		}
+		service, err := s.serviceRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return []events.Event{events.ServiceEvent(events.ServiceUpdated, service)}, nil
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrServiceNotFound
+	}
+	return err
+}
+
+func (s *translationService) updateGroup(ctx context.Context, id string, write func(ctx context.Context) error) error {
+	err := s.recorder.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := write(ctx); err != nil {
+			return nil, err
+		}
+		group, err := s.serviceGroupRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return []events.Event{events.GroupEvent(events.GroupUpdated, group)}, nil
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrServiceGroupNotFound
+	}
+	return err
+}
+
+func (s *translationService) checkLocale(locale string) (string, error) {
+	if !i18n.IsValid(locale) || !s.localeResolver.IsSupported(locale) {
+		return "", ErrUnsupportedLocale
+	}
+	return i18n.Normalize(locale), nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"services-management/internal/gateway"
+	"services-management/internal/gateway/dto"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/logger"
+	"services-management/pkg/constants"
+	"services-management/pkg/urltemplate"
+)
+
+// urlValues giá trị placeholder của user hiện tại trong một request.
+// Claim trong JWT dùng trước, chỉ gọi user gateway khi template cần và gọi tối đa một lần.
+// {organization_id} luôn là organization đang active của user, không nhận từ request
+type urlValues struct {
+	ctx         context.Context
+	userGateway gateway.UserGateway
+
+	currentUser *dto.CurrentUser
+	userLoaded  bool
+	teacherID   *string
+	staffID     *string
+}
+
+// membership hồ sơ teacher/staff của user trong một organization
+type membership struct {
+	ID             string
+	OrganizationID string
+}
+
+// newURLValues user đã lấy từ user gateway thì truyền vào để không gọi lại, nil thì lấy khi cần
+func newURLValues(ctx context.Context, userGateway gateway.UserGateway, user *dto.CurrentUser) *urlValues {
+	return &urlValues{
+		ctx:         ctx,
+		userGateway: userGateway,
+		currentUser: user,
+		userLoaded:  user != nil,
+	}
+}
+
+func (v *urlValues) lookup(name string) (string, bool) {
+	switch name {
+	case urltemplate.UserID:
+		if id := userIDFromContext(v.ctx); id != "" {
+			return id, true
+		}
+		return v.userField(func(u *dto.CurrentUser) string { return u.ID })
+	case urltemplate.Username:
+		if userName, _ := v.ctx.Value(constants.UserName).(string); userName != "" {
+			return userName, true
+		}
+		return v.userField(func(u *dto.CurrentUser) string { return u.Username })
+	case urltemplate.Email:
+		return v.userField(func(u *dto.CurrentUser) string { return u.Email })
+	case urltemplate.Fullname:
+		return v.userField(func(u *dto.CurrentUser) string { return u.Fullname })
+	case urltemplate.OrganizationID:
+		return v.userField(func(u *dto.CurrentUser) string { return u.OrganizationIdActive })
+	case urltemplate.TeacherID:
+		if v.teacherID == nil {
+			id := v.memberID(v.loadTeacherOrgs)
+			v.teacherID = &id
+		}
+		return *v.teacherID, *v.teacherID != ""
+	case urltemplate.StaffID:
+		if v.staffID == nil {
+			id := v.memberID(v.loadStaffOrgs)
+			v.staffID = &id
+		}
+		return *v.staffID, *v.staffID != ""
+	}
+	return "", false
+}
+
+// render url của service, url không có placeholder giữ nguyên
+func (v *urlValues) render(rawUrl string) (string, error) {
+	if !urltemplate.HasPlaceholders(rawUrl) {
+		return rawUrl, nil
+	}
+	return urltemplate.Render(rawUrl, v.lookup)
+}
+
+// renderService chọn url theo môi trường rồi render cho catalog, service không đủ giá trị thì để url rỗng
+func (v *urlValues) renderService(svc *response.ServiceResDto) {
+	url, err := v.render(environmentUrl(v.ctx, svc.Url, svc.Urls))
+	if err != nil {
+		logger.WriteLogEx("warn", "render service url failed", map[string]any{
+			"service_id": svc.ID,
+			"error":      err.Error(),
+		})
+	}
+	svc.Url = url
+	// user chỉ cần url của môi trường hiện tại
+	svc.Urls = nil
+}
+
+// environmentUrl url của môi trường trong context, không có thì dùng url mặc định
+func environmentUrl(ctx context.Context, defaultUrl string, urls map[string]string) string {
+	env, _ := ctx.Value(constants.Environment).(string)
+	if url, ok := urls[env]; ok && url != "" {
+		return url
+	}
+	return defaultUrl
+}
+
+func (v *urlValues) user() *dto.CurrentUser {
+	if !v.userLoaded {
+		v.userLoaded = true
+		user, err := v.userGateway.GetCurrentUser(v.ctx)
+		if err != nil {
+			v.logGatewayError("get current user", err)
+		}
+		v.currentUser = user
+	}
+	return v.currentUser
+}
+
+func (v *urlValues) userField(field func(u *dto.CurrentUser) string) (string, bool) {
+	user := v.user()
+	if user == nil {
+		return "", false
+	}
+	value := field(user)
+	return value, value != ""
+}
+
+// memberID id teacher/staff của user trong organization đang dùng.
+// Không xác định được organization thì chỉ nhận khi user có đúng một hồ sơ
+func (v *urlValues) memberID(load func(userID string) ([]membership, error)) string {
+	userID, _ := v.lookup(urltemplate.UserID)
+	if userID == "" {
+		return ""
+	}
+	members, err := load(userID)
+	if err != nil {
+		v.logGatewayError("get members by user", err)
+		return ""
+	}
+
+	orgID, _ := v.lookup(urltemplate.OrganizationID)
+	if orgID == "" {
+		if len(members) == 1 {
+			return members[0].ID
+		}
+		return ""
+	}
+	for _, m := range members {
+		if m.OrganizationID == orgID {
+			return m.ID
+		}
+	}
+	return ""
+}
+
+func (v *urlValues) loadTeacherOrgs(userID string) ([]membership, error) {
+	teachers, err := v.userGateway.GetTeachersByUser(v.ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]membership, 0, len(teachers))
+	for _, t := range teachers {
+		if t != nil {
+			result = append(result, membership{ID: t.ID, OrganizationID: t.OrganizationID})
+		}
+	}
+	return result, nil
+}
+
+func (v *urlValues) loadStaffOrgs(userID string) ([]membership, error) {
+	staffs, err := v.userGateway.GetStaffsByUser(v.ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]membership, 0, len(staffs))
+	for _, s := range staffs {
+		if s != nil {
+			result = append(result, membership{ID: s.ID, OrganizationID: s.OrganizationID})
+		}
+	}
+	return result, nil
+}
+
+func (v *urlValues) logGatewayError(action string, err error) {
+	logger.WriteLogEx("error", "user gateway failed", map[string]any{
+		"action": action,
+		"error":  err.Error(),
+	})
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"services-management/internal/gateway"
+	"services-management/internal/gateway/dto"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/mapper"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/pkg/constants"
+	"services-management/pkg/i18n"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Title của nhóm Favorites theo locale
+var favoritesTitles = map[string]string{
+	"vi": "Yêu thích",
+	"en": "Favorites",
+}
+
+type UserCatalogService interface {
+	GetCatalog(ctx context.Context, req request.GetCatalogRequest) ([]*response.ServicesResponse, error)
+	GetFavorites(ctx context.Context, req request.GetCatalogRequest) ([]*response.ServiceResDto, error)
+	AddFavorite(ctx context.Context, serviceID string) error
+	RemoveFavorite(ctx context.Context, serviceID string) error
+	ReorderFavorites(ctx context.Context, req request.ReorderFavoritesRequest) error
+	SetOrdering(ctx context.Context, req request.SetOrderingRequest) error
+	ResetOrdering(ctx context.Context) error
+}
+
+type userCatalogService struct {
+	preferenceRepo   repository.UserPreferenceRepository
+	serviceRepo      repository.ServiceRepository
+	serviceGroupRepo repository.ServiceGroupRepository
+	localeResolver   *i18n.Resolver
+	userGateway      gateway.UserGateway
+	announcementRepo repository.AnnouncementRepository
+	collectionRepo   repository.SmartCollectionRepository
+}
+
+func NewUserCatalogService(
+	preferenceRepo repository.UserPreferenceRepository,
+	serviceRepo repository.ServiceRepository,
+	serviceGroupRepo repository.ServiceGroupRepository,
+	localeResolver *i18n.Resolver,
+	userGateway gateway.UserGateway,
+	announcementRepo repository.AnnouncementRepository,
+	collectionRepo repository.SmartCollectionRepository,
+) UserCatalogService {
+	return &userCatalogService{
+		preferenceRepo:   preferenceRepo,
+		serviceRepo:      serviceRepo,
+		serviceGroupRepo: serviceGroupRepo,
+		localeResolver:   localeResolver,
+		userGateway:      userGateway,
+		announcementRepo: announcementRepo,
+		collectionRepo:   collectionRepo,
+	}
+}
+
+// GetCatalog catalog của user hiện tại: chỉ service active và role của user được thấy,
+// nhóm Favorites ở đầu, tiếp theo là smart collection, group/service sắp theo thứ tự riêng
+// của user trước rồi tới thứ tự admin
+func (s *userCatalogService) GetCatalog(ctx context.Context, req request.GetCatalogRequest) ([]*response.ServicesResponse, error) {
+	pref, err := s.preferenceRepo.GetByUserID(ctx, userIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.serviceGroupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, services, err := s.visibleServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chỉ giữ group có service user được thấy
+	hasServices := make(map[string]struct{}, len(groups))
+	for _, svc := range services {
+		hasServices[svc.GroupID] = struct{}{}
+	}
+	visibleGroups := make([]*model.ServiceGroup, 0, len(groups))
+	for _, g := range groups {
+		if _, ok := hasServices[g.ID.Hex()]; ok {
+			visibleGroups = append(visibleGroups, g)
+		}
+	}
+
+	sortByPersonalOrder(visibleGroups, pref.GroupOrders, func(g *model.ServiceGroup) (string, int) {
+		return g.ID.Hex(), g.Order
+	})
+	sortByPersonalOrder(services, pref.ServiceOrders, func(svc *model.Service) (string, int) {
+		return svc.ID.Hex(), svc.Order
+	})
+
+	locales := s.localeResolver.Chain(req.Locales...)
+	catalog := mapper.MapServicesResponse(visibleGroups, services, locales...)
+
+	collections, err := s.collectionRepo.Find(ctx, repository.SmartCollectionFilter{OrganizationID: user.OrganizationIdActive})
+	if err != nil {
+		return nil, err
+	}
+	catalog = append(collectionsResponse(collections, user.OrganizationIdActive, services, locales), catalog...)
+
+	favorites := favoriteServices(services, pref.Favorites)
+	if len(favorites) > 0 {
+		favoritesGroup := &response.ServicesResponse{
+			Group: response.ServiceGroupResponse{
+				ID:    constants.FavoritesGroupID,
+				Title: favoritesTitle(locales),
+			},
+		}
+		for _, svc := range mapper.MapServicesToServiceResDtos(favorites, locales...) {
+			favoritesGroup.Services = append(favoritesGroup.Services, *svc)
+		}
+		catalog = append([]*response.ServicesResponse{favoritesGroup}, catalog...)
+	}
+
+	announcements, err := loadAnnouncements(ctx, s.announcementRepo)
+	if err != nil {
+		return nil, err
+	}
+	announcements.applyToCatalog(catalog, serviceGroupIDs(services), groupsByID(groups))
+
+	favoriteSet := toSet(pref.Favorites)
+	values := newURLValues(ctx, s.userGateway, user)
+	for _, item := range catalog {
+		for i := range item.Services {
+			_, item.Services[i].IsFavorite = favoriteSet[item.Services[i].ID]
+			values.renderService(&item.Services[i])
+		}
+	}
+	return catalog, nil
+}
+
+func (s *userCatalogService) GetFavorites(ctx context.Context, req request.GetCatalogRequest) ([]*response.ServiceResDto, error) {
+	pref, err := s.preferenceRepo.GetByUserID(ctx, userIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	user, services, err := s.visibleServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.serviceGroupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	announcements, err := loadAnnouncements(ctx, s.announcementRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := mapper.MapServicesToServiceResDtos(favoriteServices(services, pref.Favorites), s.localeResolver.Chain(req.Locales...)...)
+	serviceGroups, groupMap := serviceGroupIDs(services), groupsByID(groups)
+	values := newURLValues(ctx, s.userGateway, user)
+	for _, svc := range result {
+		svc.IsFavorite = true
+		announcements.applyToService(svc, serviceGroups, groupMap)
+		values.renderService(svc)
+	}
+	return result, nil
+}
+
+func (s *userCatalogService) AddFavorite(ctx context.Context, serviceID string) error {
+	_, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return ErrServiceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.preferenceRepo.AddFavorite(ctx, userIDFromContext(ctx), serviceID)
+}
+
+func (s *userCatalogService) RemoveFavorite(ctx context.Context, serviceID string) error {
+	return s.preferenceRepo.RemoveFavorite(ctx, userIDFromContext(ctx), serviceID)
+}
+
+// ReorderFavorites service_ids phải đúng bằng danh sách favorites hiện tại
+func (s *userCatalogService) ReorderFavorites(ctx context.Context, req request.ReorderFavoritesRequest) error {
+	userID := userIDFromContext(ctx)
+	pref, err := s.preferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	current := toSet(pref.Favorites)
+	requested := toSet(req.ServiceIDs)
+	if len(requested) != len(req.ServiceIDs) || len(requested) != len(current) {
+		return ErrInvalidFavorites
+	}
+	for id := range requested {
+		if _, ok := current[id]; !ok {
+			return ErrInvalidFavorites
+		}
+	}
+	return s.preferenceRepo.SetFavorites(ctx, userID, req.ServiceIDs)
+}
+
+func (s *userCatalogService) SetOrdering(ctx context.Context, req request.SetOrderingRequest) error {
+	return s.preferenceRepo.SetOrders(ctx, userIDFromContext(ctx), positions(req.GroupIDs), positions(req.ServiceIDs))
+}
+
+func (s *userCatalogService) ResetOrdering(ctx context.Context) error {
+	return s.preferenceRepo.SetOrders(ctx, userIDFromContext(ctx), nil, nil)
+}
+
+// visibleServices service active user được thấy trong organization đang dùng của user
+func (s *userCatalogService) visibleServices(ctx context.Context) (*dto.CurrentUser, []*model.Service, error) {
+	user, err := currentUser(ctx, s.userGateway)
+	if err != nil {
+		return nil, nil, err
+	}
+	organizationID := user.OrganizationIdActive
+
+	services, _, err := s.serviceRepo.Find(ctx, repository.ServiceFilter{
+		OrganizationID: organizationID,
+		Status:         string(constants.ServiceStatusActive),
+		Roles:          rolesFromContext(ctx),
+	}, repository.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// filter rỗng của repository là mọi organization, user chưa có organization chỉ thấy service dùng chung
+	result := make([]*model.Service, 0, len(services))
+	for _, svc := range services {
+		if sameOrganization(svc.OrganizationID, organizationID) {
+			result = append(result, svc)
+		}
+	}
+	return user, result, nil
+}
+
+// sortByPersonalOrder item có order riêng của user đứng trước, còn lại giữ thứ tự admin
+func sortByPersonalOrder[T any](items []T, personal map[string]int, key func(T) (string, int)) {
+	rank := func(item T) (int, int) {
+		id, order := key(item)
+		if p, ok := personal[id]; ok {
+			return p, order
+		}
+		return math.MaxInt, order
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		pi, oi := rank(items[i])
+		pj, oj := rank(items[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return oi < oj
+	})
+}
+
+// favoriteServices lấy các service user được thấy theo thứ tự favorites
+func favoriteServices(services []*model.Service, favorites []string) []*model.Service {
+	byID := make(map[string]*model.Service, len(services))
+	for _, svc := range services {
+		byID[svc.ID.Hex()] = svc
+	}
+
+	result := make([]*model.Service, 0, len(favorites))
+	for _, id := range favorites {
+		if svc, ok := byID[id]; ok {
+			result = append(result, svc)
+		}
+	}
+	return result
+}
+
+func favoritesTitle(locales []string) string {
+	for _, l := range locales {
+		if title, ok := favoritesTitles[l]; ok {
+			return title
+		}
+	}
+	return favoritesTitles["en"]
+}
+
+// collectionsResponse bỏ collection của organization khác và collection không có service nào user được thấy
+func collectionsResponse(collections []*model.SmartCollection, organizationID string, services []*model.Service, locales []string) []*response.ServicesResponse {
+	result := make([]*response.ServicesResponse, 0, len(collections))
+	for _, c := range collections {
+		if !sameOrganization(c.OrganizationID, organizationID) {
+			continue
+		}
+		matched := collectionServices(c, services)
+		if len(matched) == 0 {
+			continue
+		}
+		item := &response.ServicesResponse{Group: mapper.MapCollectionGroupResponse(c, locales...)}
+		for _, svc := range mapper.MapServicesToServiceResDtos(matched, locales...) {
+			item.Services = append(item.Services, *svc)
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func serviceGroupIDs(services []*model.Service) map[string]string {
+	result := make(map[string]string, len(services))
+	for _, svc := range services {
+		result[svc.ID.Hex()] = svc.GroupID
+	}
+	return result
+}
+
+func groupsByID(groups []*model.ServiceGroup) map[string]*model.ServiceGroup {
+	result := make(map[string]*model.ServiceGroup, len(groups))
+	for _, g := range groups {
+		result[g.ID.Hex()] = g
+	}
+	return result
+}
+
+func positions(ids []string) map[string]int {
+	result := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, ok := result[id]; !ok {
+			result[id] = i + 1
+		}
+	}
+	return result
+}
+
+func toSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
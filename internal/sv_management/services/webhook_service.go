@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"services-management/internal/sv_management/dto/request"
+	"services-management/internal/sv_management/dto/response"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/internal/sv_management/webhook"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, req request.WebhookRequest) (*response.WebhookResDto, error)
+	Update(ctx context.Context, id string, req request.WebhookRequest) (*response.WebhookResDto, error)
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*response.WebhookResDto, error)
+	List(ctx context.Context) ([]*response.WebhookResDto, error)
+	// Deliveries delivery log của một subscription, mới nhất trước
+	Deliveries(ctx context.Context, id string, req request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error)
+	// DeadLetters delivery đã hết số lần retry của mọi subscription
+	DeadLetters(ctx context.Context, req request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error)
+	// Redeliver đưa delivery trong dead-letter về hàng đợi để gửi lại
+	Redeliver(ctx context.Context, deliveryID string) error
+}
+
+type webhookService struct {
+	subscriptionRepo repository.WebhookRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	dispatcher       *webhook.Dispatcher
+}
+
+func NewWebhookService(subscriptionRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository, dispatcher *webhook.Dispatcher) WebhookService {
+	return &webhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		dispatcher:       dispatcher,
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, req request.WebhookRequest) (*response.WebhookResDto, error) {
+	subscription := &model.WebhookSubscription{
+		ID:     primitive.NewObjectID(),
+		Active: true,
+	}
+	if err := applyWebhook(subscription, req); err != nil {
+		return nil, err
+	}
+
+	generatedSecret := ""
+	if subscription.Secret == "" {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret, generatedSecret = secret, secret
+	}
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	result := webhookResDto(subscription)
+	result.Secret = generatedSecret
+	return result, nil
+}
+
+func (s *webhookService) Update(ctx context.Context, id string, req request.WebhookRequest) (*response.WebhookResDto, error) {
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWebhook(subscription, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhookResDto(subscription), nil
+}
+
+// Delete xoá subscription kèm delivery log của nó
+func (s *webhookService) Delete(ctx context.Context, id string) error {
+	err := s.subscriptionRepo.Delete(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return ErrWebhookNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.deliveryRepo.DeleteBySubscription(ctx, id)
+}
+
+func (s *webhookService) Get(ctx context.Context, id string) (*response.WebhookResDto, error) {
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return webhookResDto(subscription), nil
+}
+
+func (s *webhookService) List(ctx context.Context) ([]*response.WebhookResDto, error) {
+	subscriptions, err := s.subscriptionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.WebhookResDto, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		result = append(result, webhookResDto(sub))
+	}
+	return result, nil
+}
+
+func (s *webhookService) Deliveries(ctx context.Context, id string, req request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error) {
+	if _, err := s.getSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.findDeliveries(ctx, repository.WebhookDeliveryFilter{SubscriptionID: id, Status: req.Status}, req)
+}
+
+func (s *webhookService) DeadLetters(ctx context.Context, req request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error) {
+	return s.findDeliveries(ctx, repository.WebhookDeliveryFilter{Status: model.DeliveryStatusDead}, req)
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, deliveryID string) error {
+	err := s.deliveryRepo.Requeue(ctx, deliveryID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.dispatcher.Notify()
+	return nil
+}
+
+func (s *webhookService) findDeliveries(ctx context.Context, filter repository.WebhookDeliveryFilter, req request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error) {
+	opts := pageOptions(req.Page, req.Size)
+	deliveries, total, err := s.deliveryRepo.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*response.WebhookDeliveryResDto, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, webhookDeliveryResDto(d))
+	}
+	return &response.WebhookDeliveryListResponse{
+		Items: items,
+		Pagination: response.PaginationResponse{
+			Page:  opts.Page,
+			Size:  opts.Size,
+			Total: total,
+		},
+	}, nil
+}
+
+func (s *webhookService) getSubscription(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return nil, ErrWebhookNotFound
+	}
+	return subscription, err
+}
+
+// applyWebhook kiểm tra url và event filter rồi ghi request vào subscription
+func applyWebhook(subscription *model.WebhookSubscription, req request.WebhookRequest) error {
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) url", ErrInvalidWebhook)
+	}
+
+	filters := []string{}
+	for _, f := range req.Events {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if !events.ValidFilter(f) {
+			return fmt.Errorf("%w: unknown event %q, expected one of %s", ErrInvalidWebhook, f, strings.Join(events.Types, ", "))
+		}
+		if !slices.Contains(filters, f) {
+			filters = append(filters, f)
+		}
+	}
+
+	subscription.Url = req.Url
+	subscription.Events = filters
+	subscription.Description = strings.TrimSpace(req.Description)
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	return nil
+}
+
+func webhookResDto(sub *model.WebhookSubscription) *response.WebhookResDto {
+	return &response.WebhookResDto{
+		ID:          sub.ID.Hex(),
+		Url:         sub.Url,
+		Events:      sub.Events,
+		Active:      sub.Active,
+		Description: sub.Description,
+		CreatedAt:   sub.CreatedAt,
+		UpdatedAt:   sub.UpdatedAt,
+	}
+}
+
+func webhookDeliveryResDto(d *model.WebhookDelivery) *response.WebhookDeliveryResDto {
+	attempts := make([]response.WebhookAttemptResDto, 0, len(d.Attempts))
+	for _, a := range d.Attempts {
+		attempts = append(attempts, response.WebhookAttemptResDto{
+			AttemptedAt: a.AttemptedAt,
+			StatusCode:  a.StatusCode,
+			DurationMs:  a.DurationMs,
+			Error:       a.Error,
+		})
+	}
+	return &response.WebhookDeliveryResDto{
+		ID:             d.ID.Hex(),
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Payload:        d.Payload,
+		Attempts:       attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -0,0 +1,260 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"services-management/internal/sv_management/model"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+
+	DefaultTTL   = 60 * time.Second
+	DefaultParam = "sso_token"
+	Issuer       = "services-management"
+)
+
+// Claim nguồn có thể map sang tên claim của đối tác
+const (
+	ClaimUserID         = "user_id"
+	ClaimName           = "name"
+	ClaimRoles          = "roles"
+	ClaimOrganizationID = "organization_id"
+)
+
+var sourceClaims = map[string]struct{}{
+	ClaimUserID:         {},
+	ClaimName:           {},
+	ClaimRoles:          {},
+	ClaimOrganizationID: {},
+}
+
+// reservedClaims claim chuẩn của JWT, không cho map đè
+var reservedClaims = map[string]struct{}{
+	"iss": {}, "sub": {}, "aud": {}, "exp": {}, "nbf": {}, "iat": {}, "jti": {},
+}
+
+var (
+	ErrInvalidConfig = errors.New("invalid sso config")
+	ErrInvalidToken  = errors.New("invalid sso token")
+)
+
+// Identity thông tin user gắn vào token
+type Identity struct {
+	UserID         string
+	Name           string
+	Roles          []string
+	OrganizationID string
+}
+
+// ValidateClaimMapping chỉ cho map claim nguồn đã biết sang tên không trùng claim chuẩn
+func ValidateClaimMapping(mapping map[string]string) error {
+	targets := map[string]struct{}{}
+	for source, target := range mapping {
+		if _, ok := sourceClaims[source]; !ok {
+			return fmt.Errorf("%w: unknown claim %q", ErrInvalidConfig, source)
+		}
+		if _, ok := reservedClaims[target]; ok || target == "" {
+			return fmt.Errorf("%w: claim %q cannot be mapped to %q", ErrInvalidConfig, source, target)
+		}
+		if _, ok := targets[target]; ok {
+			return fmt.Errorf("%w: duplicate target claim %q", ErrInvalidConfig, target)
+		}
+		targets[target] = struct{}{}
+	}
+	return nil
+}
+
+// GenerateSecret secret ngẫu nhiên 32 byte cho HS256
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GeneratePrivateKey RSA 2048 dạng PEM PKCS#8 cho RS256
+func GeneratePrivateKey() (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// PublicKeyPEM public key để đối tác verify token RS256
+func PublicKeyPEM(cfg *model.SSOConfig) (string, error) {
+	key, err := parsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// ValidateKey kiểm tra secret/private key dùng được với thuật toán đã chọn
+func ValidateKey(cfg *model.SSOConfig) error {
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		if len(cfg.Secret) < 32 {
+			return fmt.Errorf("%w: secret must be at least 32 characters", ErrInvalidConfig)
+		}
+		return nil
+	case AlgorithmRS256:
+		_, err := parsePrivateKey(cfg.PrivateKey)
+		return err
+	}
+	return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidConfig, cfg.Algorithm)
+}
+
+// NewNonce jti ngẫu nhiên của token
+func NewNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Sign ký launch token, kid là service id để lúc verify biết dùng config nào
+func Sign(serviceID string, cfg *model.SSOConfig, identity Identity, nonce string, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(TTL(cfg))
+	claims := jwt.MapClaims{
+		"iss": Issuer,
+		"sub": identity.UserID,
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": expiresAt.Unix(),
+		"jti": nonce,
+	}
+	if cfg.Audience != "" {
+		claims["aud"] = cfg.Audience
+	}
+	for source, value := range map[string]any{
+		ClaimUserID:         identity.UserID,
+		ClaimName:           identity.Name,
+		ClaimRoles:          identity.Roles,
+		ClaimOrganizationID: identity.OrganizationID,
+	} {
+		name := source
+		if target, ok := cfg.ClaimMapping[source]; ok {
+			name = target
+		}
+		claims[name] = value
+	}
+
+	var token *jwt.Token
+	var key any
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		token = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		key = []byte(cfg.Secret)
+	case AlgorithmRS256:
+		privateKey, err := parsePrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		key = privateKey
+	default:
+		return "", time.Time{}, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidConfig, cfg.Algorithm)
+	}
+	token.Header["kid"] = serviceID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// KeyID đọc kid (service id) trong header, chưa verify chữ ký
+func KeyID(tokenString string) (string, error) {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return "", fmt.Errorf("%w: missing kid", ErrInvalidToken)
+	}
+	return kid, nil
+}
+
+// Verify kiểm tra chữ ký, thời hạn, issuer và audience, trả về claims
+func Verify(tokenString string, cfg *model.SSOConfig) (jwt.MapClaims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{cfg.Algorithm}),
+		jwt.WithIssuer(Issuer),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (any, error) {
+		if cfg.Algorithm == AlgorithmHS256 {
+			return []byte(cfg.Secret), nil
+		}
+		key, err := parsePrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return &key.PublicKey, nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+func TTL(cfg *model.SSOConfig) time.Duration {
+	if cfg.TTLSeconds > 0 {
+		return time.Duration(cfg.TTLSeconds) * time.Second
+	}
+	return DefaultTTL
+}
+
+func Param(cfg *model.SSOConfig) string {
+	if cfg.Param != "" {
+		return cfg.Param
+	}
+	return DefaultParam
+}
+
+func parsePrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("%w: private key must be PEM encoded", ErrInvalidConfig)
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+			return rsaKey, nil
+		}
+		return nil, fmt.Errorf("%w: private key must be RSA", ErrInvalidConfig)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: cannot parse private key", ErrInvalidConfig)
+}
@@ -0,0 +1,210 @@
+package sso
+
+import (
+	"errors"
+	"fmt"
+	"services-management/internal/sv_management/model"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+var (
+	privateKeyOnce sync.Once
+	privateKey     string
+)
+
+// testPrivateKey sinh RSA key một lần cho cả package, GeneratePrivateKey khá chậm
+func testPrivateKey(t *testing.T) string {
+	t.Helper()
+	var err error
+	privateKeyOnce.Do(func() {
+		privateKey, err = GeneratePrivateKey()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privateKey
+}
+
+func hs256Config() *model.SSOConfig {
+	return &model.SSOConfig{Enabled: true, Algorithm: AlgorithmHS256, Secret: testSecret}
+}
+
+func rs256Config(t *testing.T) *model.SSOConfig {
+	return &model.SSOConfig{Enabled: true, Algorithm: AlgorithmRS256, PrivateKey: testPrivateKey(t)}
+}
+
+var testIdentity = Identity{
+	UserID:         "u1",
+	Name:           "Nguyễn Văn A",
+	Roles:          []string{"teacher", "admin"},
+	OrganizationID: "org-1",
+}
+
+func TestSignVerify(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   func(t *testing.T) *model.SSOConfig
+		claim string // tên claim chứa user id sau khi map
+	}{
+		{name: "HS256", cfg: func(*testing.T) *model.SSOConfig { return hs256Config() }, claim: ClaimUserID},
+		{name: "RS256", cfg: rs256Config, claim: ClaimUserID},
+		{name: "audience", cfg: func(*testing.T) *model.SSOConfig {
+			cfg := hs256Config()
+			cfg.Audience = "partner.example.com"
+			return cfg
+		}, claim: ClaimUserID},
+		{name: "claim mapping", cfg: func(*testing.T) *model.SSOConfig {
+			cfg := hs256Config()
+			cfg.ClaimMapping = map[string]string{ClaimUserID: "employee_id"}
+			return cfg
+		}, claim: "employee_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg(t)
+			now := time.Now()
+			token, expiresAt, err := Sign("svc-1", cfg, testIdentity, "nonce-1", now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := now.Add(DefaultTTL); !expiresAt.Equal(want) {
+				t.Fatalf("expiresAt = %v, want %v", expiresAt, want)
+			}
+
+			kid, err := KeyID(token)
+			if err != nil || kid != "svc-1" {
+				t.Fatalf("KeyID = %q, %v, want svc-1", kid, err)
+			}
+			claims, err := Verify(token, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims[tt.claim] != "u1" || claims["sub"] != "u1" || claims["jti"] != "nonce-1" {
+				t.Fatalf("claims = %v, want %s=u1, sub=u1, jti=nonce-1", claims, tt.claim)
+			}
+			if tt.claim != ClaimUserID {
+				if _, ok := claims[ClaimUserID]; ok {
+					t.Fatalf("claims = %v, mapped claim %s still present", claims, ClaimUserID)
+				}
+			}
+			if fmt.Sprint(claims[ClaimRoles]) != "[teacher admin]" {
+				t.Fatalf("roles = %v, want [teacher admin]", claims[ClaimRoles])
+			}
+		})
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		sign   func(t *testing.T) (string, error)
+		verify func(t *testing.T) *model.SSOConfig
+	}{
+		{
+			name: "sai secret",
+			sign: func(*testing.T) (string, error) {
+				token, _, err := Sign("svc-1", hs256Config(), testIdentity, "n", time.Now())
+				return token, err
+			},
+			verify: func(*testing.T) *model.SSOConfig {
+				cfg := hs256Config()
+				cfg.Secret = strings.Repeat("x", 32)
+				return cfg
+			},
+		},
+		{
+			name: "hết hạn",
+			sign: func(*testing.T) (string, error) {
+				token, _, err := Sign("svc-1", hs256Config(), testIdentity, "n", time.Now().Add(-2*DefaultTTL))
+				return token, err
+			},
+			verify: func(*testing.T) *model.SSOConfig { return hs256Config() },
+		},
+		{
+			name: "sai audience",
+			sign: func(*testing.T) (string, error) {
+				cfg := hs256Config()
+				cfg.Audience = "a.example.com"
+				token, _, err := Sign("svc-1", cfg, testIdentity, "n", time.Now())
+				return token, err
+			},
+			verify: func(*testing.T) *model.SSOConfig {
+				cfg := hs256Config()
+				cfg.Audience = "b.example.com"
+				return cfg
+			},
+		},
+		{
+			name: "khác thuật toán",
+			sign: func(*testing.T) (string, error) {
+				token, _, err := Sign("svc-1", hs256Config(), testIdentity, "n", time.Now())
+				return token, err
+			},
+			verify: rs256Config,
+		},
+		{
+			name: "payload bị sửa",
+			sign: func(*testing.T) (string, error) {
+				token, _, err := Sign("svc-1", hs256Config(), testIdentity, "n", time.Now())
+				parts := strings.Split(token, ".")
+				other, _, _ := Sign("svc-1", hs256Config(), Identity{UserID: "u2"}, "n", time.Now())
+				parts[1] = strings.Split(other, ".")[1]
+				return strings.Join(parts, "."), err
+			},
+			verify: func(*testing.T) *model.SSOConfig { return hs256Config() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.sign(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Verify(token, tt.verify(t)); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify error = %v, want %v", err, ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestKeyIDRejectsMalformedToken(t *testing.T) {
+	for _, token := range []string{"", "not-a-jwt", "a.b.c"} {
+		if _, err := KeyID(token); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("KeyID(%q) error = %v, want %v", token, err, ErrInvalidToken)
+		}
+	}
+}
+
+func TestValidateClaimMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[string]string
+		valid   bool
+	}{
+		{name: "rỗng", mapping: nil, valid: true},
+		{name: "map claim đã biết", mapping: map[string]string{ClaimUserID: "uid", ClaimRoles: "groups"}, valid: true},
+		{name: "claim nguồn không biết", mapping: map[string]string{"email": "mail"}},
+		{name: "map đè claim chuẩn", mapping: map[string]string{ClaimUserID: "sub"}},
+		{name: "tên đích rỗng", mapping: map[string]string{ClaimName: ""}},
+		{name: "trùng tên đích", mapping: map[string]string{ClaimUserID: "id", ClaimOrganizationID: "id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClaimMapping(tt.mapping)
+			if tt.valid && err != nil {
+				t.Fatalf("error = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package tracking
+
+import (
+	"context"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"time"
+)
+
+const (
+	defaultRollupInterval = 5 * time.Minute
+	rollupTimeout         = 5 * time.Minute
+	dayLayout             = "2006-01-02"
+)
+
+// RollupJob định kỳ tính lại rollup usage từ click_events.
+// Mỗi lần chạy tính lại từ ngày trước ngày rollup mới nhất để gom cả click ghi trễ qua buffer
+type RollupJob struct {
+	clickRepo  repository.ClickEventRepository
+	rollupRepo repository.UsageRollupRepository
+	interval   time.Duration
+}
+
+func NewRollupJob(clickRepo repository.ClickEventRepository, rollupRepo repository.UsageRollupRepository, interval time.Duration) *RollupJob {
+	if interval <= 0 {
+		interval = defaultRollupInterval
+	}
+	return &RollupJob{
+		clickRepo:  clickRepo,
+		rollupRepo: rollupRepo,
+		interval:   interval,
+	}
+}
+
+// Start chạy job tới khi ctx bị huỷ
+func (j *RollupJob) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.runOnce(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *RollupJob) runOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, rollupTimeout)
+	defer cancel()
+
+	if err := j.Run(ctx); err != nil {
+		logger.WriteLogEx("error", "usage rollup failed", map[string]any{
+			"error": err.Error(),
+		})
+	}
+}
+
+// Run tính lại rollup một lần, dùng cho cả job định kỳ và API chạy tay
+func (j *RollupJob) Run(ctx context.Context) error {
+	since, err := j.since(ctx)
+	if err != nil || since == nil {
+		return err
+	}
+	return j.clickRepo.RollupDaily(ctx, *since, j.rollupRepo.CollectionName())
+}
+
+func (j *RollupJob) since(ctx context.Context) (*time.Time, error) {
+	latest, err := j.rollupRepo.LatestDay(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest == "" {
+		// chưa rollup lần nào: tính từ click đầu tiên
+		return j.clickRepo.Earliest(ctx)
+	}
+
+	day, err := time.Parse(dayLayout, latest)
+	if err != nil {
+		return nil, err
+	}
+	since := day.AddDate(0, 0, -1)
+	return &since, nil
+}
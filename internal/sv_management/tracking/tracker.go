@@ -0,0 +1,126 @@
+package tracking
+
+import (
+	"context"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBufferSize    = 10000
+	defaultBatchSize     = 200
+	defaultFlushInterval = 2 * time.Second
+	writeTimeout         = 10 * time.Second
+)
+
+// Tracker ghi click event bất đồng bộ để redirect không phải chờ DB
+type Tracker interface {
+	// Track không bao giờ block, buffer đầy thì bỏ event và ghi log
+	Track(event *model.ClickEvent)
+	// Close flush các event còn trong buffer rồi dừng worker
+	Close()
+}
+
+type Options struct {
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+type bufferedTracker struct {
+	repo          repository.ClickEventRepository
+	events        chan *model.ClickEvent
+	batchSize     int
+	flushInterval time.Duration
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func NewTracker(repo repository.ClickEventRepository, opts Options) Tracker {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+
+	t := &bufferedTracker{
+		repo:          repo,
+		events:        make(chan *model.ClickEvent, opts.BufferSize),
+		batchSize:     opts.BatchSize,
+		flushInterval: opts.FlushInterval,
+		done:          make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+func (t *bufferedTracker) Track(event *model.ClickEvent) {
+	select {
+	case t.events <- event:
+	default:
+		logger.WriteLogEx("warn", "click buffer full, event dropped", map[string]any{
+			"service_id": event.ServiceID,
+			"user_id":    event.UserID,
+		})
+	}
+}
+
+func (t *bufferedTracker) Close() {
+	t.closeOnce.Do(func() {
+		close(t.events)
+		<-t.done
+	})
+}
+
+// run gom event thành batch, flush khi đủ batch hoặc hết flushInterval
+func (t *bufferedTracker) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*model.ClickEvent, 0, t.batchSize)
+	for {
+		select {
+		case event, ok := <-t.events:
+			if !ok {
+				t.flush(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= t.batchSize {
+				t.flush(batch)
+				batch = make([]*model.ClickEvent, 0, t.batchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				t.flush(batch)
+				batch = make([]*model.ClickEvent, 0, t.batchSize)
+			}
+		}
+	}
+}
+
+func (t *bufferedTracker) flush(batch []*model.ClickEvent) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	if err := t.repo.InsertMany(ctx, batch); err != nil {
+		logger.WriteLogEx("error", "write click events failed", map[string]any{
+			"count": len(batch),
+			"error": err.Error(),
+		})
+	}
+}
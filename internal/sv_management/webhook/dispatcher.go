@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/model"
+	"services-management/internal/sv_management/repository"
+	"services-management/logger"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultTimeout      = 10 * time.Second
+	defaultMaxAttempts  = 8
+	defaultBackoffBase  = 30 * time.Second
+	defaultBackoffMax   = 6 * time.Hour
+	defaultConcurrency  = 4
+	writeTimeout        = 10 * time.Second
+	// đọc tối đa bấy nhiêu byte body lỗi để ghi vào delivery log
+	maxErrorBody = 512
+)
+
+type Options struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	// MaxAttempts số lần gửi tối đa trước khi chuyển vào dead-letter
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	Concurrency int
+}
+
+// Dispatcher lưu delivery cho mỗi subscription khớp event rồi gửi bất đồng bộ,
+// retry theo exponential backoff, quá MaxAttempts thì chuyển sang dead
+type Dispatcher struct {
+	subscriptionRepo repository.WebhookRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	client           *http.Client
+	opts             Options
+	wake             chan struct{}
+	worker           sync.WaitGroup
+}
+
+// NewDispatcher client nil thì dùng client mặc định với Timeout trong opts
+func NewDispatcher(subscriptionRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository, client *http.Client, opts Options) *Dispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.BackoffBase <= 0 {
+		opts.BackoffBase = defaultBackoffBase
+	}
+	if opts.BackoffMax <= 0 {
+		opts.BackoffMax = defaultBackoffMax
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+	return &Dispatcher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		client:           client,
+		opts:             opts,
+		wake:             make(chan struct{}, 1),
+	}
+}
+
+// Publish tạo delivery cho các subscription khớp event, lỗi chỉ ghi log vì thay đổi catalog đã được lưu
+func (d *Dispatcher) Publish(ctx context.Context, event events.Event) {
+	if err := d.enqueue(ctx, event); err != nil {
+		logger.WriteLogEx("error", "enqueue webhook failed", map[string]any{
+			"event_id":   event.ID,
+			"event_type": event.Type,
+			"error":      err.Error(),
+		})
+		return
+	}
+	d.Notify()
+}
+
+func (d *Dispatcher) enqueue(ctx context.Context, event events.Event) error {
+	subscriptions, err := d.subscriptionRepo.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	now := time.Now()
+	var deliveries []*model.WebhookDelivery
+	for _, sub := range subscriptions {
+		if !events.Match(sub.Events, event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, &model.WebhookDelivery{
+			SubscriptionID: sub.ID.Hex(),
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         model.DeliveryStatusPending,
+			Attempts:       []model.WebhookAttempt{},
+			NextAttemptAt:  &now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	return d.deliveryRepo.InsertMany(ctx, deliveries)
+}
+
+// Notify đánh thức worker gửi ngay thay vì chờ lượt poll tiếp theo
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start chạy worker tới khi ctx bị huỷ
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.worker.Add(1)
+	go func() {
+		defer d.worker.Done()
+		ticker := time.NewTicker(d.opts.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := d.Run(ctx); err != nil {
+				logger.WriteLogEx("error", "webhook dispatch failed", map[string]any{
+					"error": err.Error(),
+				})
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-d.wake:
+			}
+		}
+	}()
+}
+
+// Wait chờ worker dừng hẳn sau khi ctx của Start bị huỷ, delivery đang gửi được gửi xong
+func (d *Dispatcher) Wait() {
+	d.worker.Wait()
+}
+
+// Run gửi mọi delivery đang đến hạn, tối đa Concurrency request cùng lúc
+func (d *Dispatcher) Run(ctx context.Context) error {
+	subscriptions := map[string]*model.WebhookSubscription{}
+	sem := make(chan struct{}, d.opts.Concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for ctx.Err() == nil {
+		delivery, err := d.deliveryRepo.Claim(ctx, time.Now(), d.lease())
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		sub, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			sub, err = d.subscriptionRepo.GetByID(ctx, delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return err
+			}
+			subscriptions[delivery.SubscriptionID] = sub
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *model.WebhookDelivery, sub *model.WebhookSubscription) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// delivery đã claim thì gửi nốt khi worker đang dừng, client có Timeout nên không treo
+			d.deliver(context.WithoutCancel(ctx), delivery, sub)
+		}(delivery, sub)
+	}
+	return ctx.Err()
+}
+
+// lease đủ dài để một lần gửi xong trước khi delivery được claim lại
+func (d *Dispatcher) lease() time.Duration {
+	return 2*d.opts.Timeout + writeTimeout
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery, sub *model.WebhookSubscription) {
+	var attempt model.WebhookAttempt
+	if sub == nil || !sub.Active {
+		// subscription đã xoá hoặc tắt thì không retry nữa
+		attempt = model.WebhookAttempt{AttemptedAt: time.Now(), Error: "subscription is inactive or deleted"}
+		d.record(ctx, delivery, attempt, model.DeliveryStatusDead, nil)
+		return
+	}
+
+	attempt = d.send(ctx, delivery, sub)
+	if attempt.Error == "" {
+		d.record(ctx, delivery, attempt, model.DeliveryStatusSucceeded, nil)
+		return
+	}
+
+	attempts := len(delivery.Attempts) + 1
+	if attempts >= d.opts.MaxAttempts {
+		d.record(ctx, delivery, attempt, model.DeliveryStatusDead, nil)
+		return
+	}
+	next := time.Now().Add(d.Backoff(attempts))
+	d.record(ctx, delivery, attempt, model.DeliveryStatusPending, &next)
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *model.WebhookDelivery, sub *model.WebhookSubscription) model.WebhookAttempt {
+	start := time.Now()
+	attempt := model.WebhookAttempt{AttemptedAt: start}
+
+	body := []byte(delivery.Payload)
+	timestamp := start.Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Url, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "services-management-webhook")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.Hex())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		attempt.Error = fmt.Sprintf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return attempt
+}
+
+// Backoff thời gian chờ sau lần gửi thất bại thứ attempt: base * 2^(attempt-1), tối đa BackoffMax
+func (d *Dispatcher) Backoff(attempt int) time.Duration {
+	wait := d.opts.BackoffBase
+	for i := 1; i < attempt && wait < d.opts.BackoffMax; i++ {
+		wait *= 2
+	}
+	return min(wait, d.opts.BackoffMax)
+}
+
+func (d *Dispatcher) record(ctx context.Context, delivery *model.WebhookDelivery, attempt model.WebhookAttempt, status string, next *time.Time) {
+	// vẫn lưu kết quả khi ctx của lượt gửi đã bị huỷ
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+
+	if err := d.deliveryRepo.RecordAttempt(writeCtx, delivery.ID, attempt, status, next); err != nil {
+		logger.WriteLogEx("error", "save webhook delivery failed", map[string]any{
+			"delivery_id": delivery.ID.Hex(),
+			"error":       err.Error(),
+		})
+	}
+	if status == model.DeliveryStatusDead {
+		logger.WriteLogEx("warn", "webhook delivery moved to dead-letter", map[string]any{
+			"delivery_id":     delivery.ID.Hex(),
+			"subscription_id": delivery.SubscriptionID,
+			"event_type":      delivery.EventType,
+			"error":           attempt.Error,
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testSecret    = "whsec_test"
+	testTimestamp = int64(1700000000)
+	testBody      = `{"id":"evt-1"}`
+)
+
+func TestSignKnownVector(t *testing.T) {
+	// tính độc lập: HMAC-SHA256("whsec_test", `1700000000.{"id":"evt-1"}`)
+	want := "sha256=5056f09710e0bebdbcd623bb1a7714db4eac94f18745b31b96dd55a69f444e14"
+	if got := Sign(testSecret, testTimestamp, []byte(testBody)); got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signature := Sign(testSecret, testTimestamp, []byte(testBody))
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		signature string
+		valid     bool
+	}{
+		{name: "đúng chữ ký", secret: testSecret, timestamp: testTimestamp, body: testBody, signature: signature, valid: true},
+		{name: "sai secret", secret: "whsec_other", timestamp: testTimestamp, body: testBody, signature: signature},
+		{name: "timestamp khác (replay)", secret: testSecret, timestamp: testTimestamp + 1, body: testBody, signature: signature},
+		{name: "body bị sửa", secret: testSecret, timestamp: testTimestamp, body: `{"id":"evt-2"}`, signature: signature},
+		{name: "thiếu tiền tố sha256=", secret: testSecret, timestamp: testTimestamp, body: testBody, signature: strings.TrimPrefix(signature, "sha256=")},
+		{name: "hex viết hoa", secret: testSecret, timestamp: testTimestamp, body: testBody, signature: "sha256=" + strings.ToUpper(strings.TrimPrefix(signature, "sha256="))},
+		{name: "chữ ký rỗng", secret: testSecret, timestamp: testTimestamp, body: testBody, signature: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.timestamp, []byte(tt.body), tt.signature); got != tt.valid {
+				t.Fatalf("Verify = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first, "whsec_") || len(first) != len("whsec_")+43 {
+		t.Fatalf("secret = %q, want whsec_ followed by 32 base64url bytes", first)
+	}
+	if first == second {
+		t.Fatal("two generated secrets are equal")
+	}
+}
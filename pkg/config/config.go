@@ -38,6 +38,108 @@ type ConsulConfig struct {
 	Port int    `yaml:"port"`
 }
 
+type SearchConfig struct {
+	Engine  string        `yaml:"engine"` // "mongo" or "elasticSearch"
+	Elastic ElasticConfig `yaml:"elastic"`
+}
+
+type ElasticConfig struct {
+	Url   string `yaml:"url"`
+	Index string `yaml:"index"`
+}
+
+type AssetConfig struct {
+	Storage   string `yaml:"storage"` // "local", S3-compatible driver sẽ bổ sung sau
+	LocalPath string `yaml:"local_path"`
+	MaxSizeMB int64  `yaml:"max_size_mb"`
+	PublicUrl string `yaml:"public_url"`
+}
+
+type I18nConfig struct {
+	DefaultLocale string   `yaml:"default_locale"`
+	Fallback      []string `yaml:"fallback"`
+	Supported     []string `yaml:"supported"`
+}
+
+type TrackingConfig struct {
+	BufferSize      int `yaml:"buffer_size"`
+	BatchSize       int `yaml:"batch_size"`
+	FlushIntervalMs int `yaml:"flush_interval_ms"`
+	// chu kỳ job tính lại rollup usage
+	RollupIntervalMs int `yaml:"rollup_interval_ms"`
+}
+
+type EnvironmentsConfig struct {
+	// Required môi trường mà mọi service bắt buộc có url riêng
+	Required []string `yaml:"required"`
+	// OverrideHeader header cho tester chọn môi trường, để trống là tắt
+	OverrideHeader string `yaml:"override_header"`
+}
+
+type HealthConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	IntervalSeconds int    `yaml:"interval_seconds"`
+	TimeoutMs       int    `yaml:"timeout_ms"`
+	Method          string `yaml:"method"` // GET hoặc HEAD, service có thể cấu hình riêng
+	Concurrency     int    `yaml:"concurrency"`
+	RetentionDays   int    `yaml:"retention_days"`
+}
+
+type WebhookConfig struct {
+	PollIntervalMs int `yaml:"poll_interval_ms"`
+	TimeoutMs      int `yaml:"timeout_ms"`
+	MaxAttempts    int `yaml:"max_attempts"` // quá số lần này delivery chuyển vào dead-letter
+	BackoffBaseMs  int `yaml:"backoff_base_ms"`
+	BackoffMaxMs   int `yaml:"backoff_max_ms"`
+	Concurrency    int `yaml:"concurrency"`
+	RetentionDays  int `yaml:"retention_days"`
+}
+
+type StreamConfig struct {
+	// HistorySize số event gần nhất giữ lại để client resume bằng Last-Event-ID
+	HistorySize      int `yaml:"history_size"`
+	HeartbeatSeconds int `yaml:"heartbeat_seconds"`
+	RetryMs          int `yaml:"retry_ms"` // thời gian EventSource chờ trước khi kết nối lại
+}
+
+type OutboxConfig struct {
+	Broker string `yaml:"broker"` // "kafka", để trống thì chỉ chuyển event trong process
+	// Transactions ghi thay đổi và outbox trong một transaction, cần MongoDB replica set.
+	// Tắt thì event bị mất nếu process dừng giữa hai lần ghi
+	Transactions   bool        `yaml:"transactions"`
+	PollIntervalMs int         `yaml:"poll_interval_ms"`
+	BatchSize      int         `yaml:"batch_size"`
+	RetentionDays  int         `yaml:"retention_days"`
+	Kafka          KafkaConfig `yaml:"kafka"`
+}
+
+type EventStoreConfig struct {
+	Store string `yaml:"store"` // "esdb" hoặc "memory", để trống là tắt event store và projection
+	// ConnectionString dạng esdb://host:2113?tls=false, env EVENT_STORE_CONNECTION_STRING ghi đè
+	ConnectionString string `yaml:"connection_string"`
+	PollIntervalMs   int    `yaml:"poll_interval_ms"`
+	BatchSize        int    `yaml:"batch_size"`
+}
+
+type CacheConfig struct {
+	Size       int         `yaml:"size"` // số entry tối đa của LRU trong process
+	TTLSeconds int         `yaml:"ttl_seconds"`
+	Redis      RedisConfig `yaml:"redis"`
+}
+
+// RedisConfig Addr để trống thì dùng LRU, env REDIS_ADDR ghi đè
+type RedisConfig struct {
+	Addr     string `yaml:"addr"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	Prefix   string `yaml:"prefix"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
 type ZapConfig struct {
 	Development bool   `mapstructure:"development"`
 	Caller      bool   `mapstructure:"caller"`
@@ -78,12 +180,23 @@ type Registry struct {
 }
 
 type AppConfigStruct struct {
-	Server   ServerConfig     `yaml:"server"`
-	Database DatabaseConfig   `yaml:"database"`
-	Consul   ConsulConfig     `yaml:"consul"`
-	Zap      ZapConfig        `mapstructure:"zap"`
-	Registry Registry         `mapstructure:"registry" validate:"required"`
-	App      AppConfiguration `mapstructure:"app"`
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	Consul       ConsulConfig       `yaml:"consul"`
+	Zap          ZapConfig          `mapstructure:"zap"`
+	Registry     Registry           `mapstructure:"registry" validate:"required"`
+	App          AppConfiguration   `mapstructure:"app"`
+	Search       SearchConfig       `yaml:"search"`
+	Asset        AssetConfig        `yaml:"asset"`
+	I18n         I18nConfig         `yaml:"i18n"`
+	Tracking     TrackingConfig     `yaml:"tracking"`
+	Environments EnvironmentsConfig `yaml:"environments"`
+	Health       HealthConfig       `yaml:"health"`
+	Webhook      WebhookConfig      `yaml:"webhook"`
+	Stream       StreamConfig       `yaml:"stream"`
+	Outbox       OutboxConfig       `yaml:"outbox"`
+	EventStore   EventStoreConfig   `yaml:"event_store"`
+	Cache        CacheConfig        `yaml:"cache"`
 }
 
 var AppConfig *AppConfigStruct
@@ -52,6 +52,13 @@ const (
 	Search = "search"
 	ID     = "id"
 
+	FavoritesGroupID    = "favorites"
+	CollectionGroupKind = "collection"
+
+	DefaultPage = 1
+	DefaultSize = 20
+	MaxSize     = 100
+
 	EsAll = "$all"
 
 	Validate        = "validate"
@@ -116,6 +123,9 @@ var (
 	UserID    ContextKey = "user_id"
 	UserName  ContextKey = "user_name"
 	UserRoles ContextKey = "roles"
+
+	// Environment môi trường dùng để chọn url của service (dev, staging, prod...)
+	Environment ContextKey = "environment"
 )
 
 type ImageMode string
@@ -149,3 +159,39 @@ func (r OwnerRole) IsValid() bool {
 		return false
 	}
 }
+
+type ServiceStatus string
+
+const (
+	ServiceStatusActive   ServiceStatus = "active"
+	ServiceStatusInactive ServiceStatus = "inactive"
+)
+
+func (s ServiceStatus) IsValid() bool {
+	switch s {
+	case ServiceStatusActive,
+		ServiceStatusInactive:
+		return true
+	default:
+		return false
+	}
+}
+
+type LaunchMode string
+
+const (
+	LaunchModeNewTab    LaunchMode = "new_tab"
+	LaunchModeEmbedded  LaunchMode = "embedded"
+	LaunchModeNativeApp LaunchMode = "native_app"
+)
+
+func (m LaunchMode) IsValid() bool {
+	switch m {
+	case LaunchModeNewTab,
+		LaunchModeEmbedded,
+		LaunchModeNativeApp:
+		return true
+	default:
+		return false
+	}
+}
@@ -7,13 +7,35 @@ import (
 	"services-management/pkg/config"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var MongoClient *mongo.Client
-var ServiceCollection *mongo.Collection
-var ServiceGroupCollection *mongo.Collection
+var MongoDatabase *mongo.Database
+
+// Tên collection, router lấy collection từ MongoDatabase theo tên
+const (
+	ServiceCollection              = "services"
+	ServiceGroupCollection         = "service_group"
+	AssetCollection                = "assets"
+	UserPreferenceCollection       = "user_preferences"
+	ClickEventCollection           = "click_events"
+	UsageRollupCollection          = "usage_rollups"
+	SSONonceCollection             = "sso_nonces"
+	HealthCheckCollection          = "health_checks"
+	AnnouncementCollection         = "announcements"
+	WebhookCollection              = "webhooks"
+	WebhookDeliveryCollection      = "webhook_deliveries"
+	OutboxCollection               = "outbox"
+	LeaseCollection                = "leases"
+	CatalogViewCollection          = "catalog_views"
+	ProjectionCheckpointCollection = "projection_checkpoints"
+	CatalogChangeCollection        = "catalog_changes"
+	CounterCollection              = "counters"
+	SmartCollectionCollection      = "smart_collections"
+)
 
 func ConnectMongoDB() {
 	d := config.AppConfig.Database.Mongo
@@ -40,7 +62,18 @@ func ConnectMongoDB() {
 		log.Fatalf("MongoDB ping failed: %v", err)
 	}
 
-	ServiceCollection = MongoClient.Database(d.Name).Collection("services")
-	ServiceGroupCollection = MongoClient.Database(d.Name).Collection("service_group")
-	log.Println("Connected to MongoDB and loaded 'services', 'service_group' collection")
+	MongoDatabase = MongoClient.Database(d.Name)
+	log.Printf("Connected to MongoDB database '%s'", d.Name)
+}
+
+// SupportsTransactions chỉ replica set hoặc sharded cluster (mongos) mới chạy được transaction
+func SupportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
 }
@@ -0,0 +1,147 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Resolver build chuỗi locale fallback: locale client yêu cầu -> fallback trong config -> locale mặc định
+type Resolver struct {
+	defaultLocale string
+	fallback      []string
+	supported     map[string]struct{}
+}
+
+func NewResolver(defaultLocale string, fallback []string, supported []string) *Resolver {
+	r := &Resolver{
+		defaultLocale: Normalize(defaultLocale),
+		supported:     make(map[string]struct{}, len(supported)),
+	}
+	for _, l := range fallback {
+		r.fallback = append(r.fallback, Normalize(l))
+	}
+	for _, l := range supported {
+		r.supported[Normalize(l)] = struct{}{}
+	}
+	return r
+}
+
+func (r *Resolver) DefaultLocale() string {
+	return r.defaultLocale
+}
+
+// Supported danh sách locale được hỗ trợ, đã sắp xếp
+func (r *Resolver) Supported() []string {
+	locales := make([]string, 0, len(r.supported))
+	for l := range r.supported {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// IsSupported config không khai báo supported thì chấp nhận mọi locale
+func (r *Resolver) IsSupported(locale string) bool {
+	if len(r.supported) == 0 {
+		return true
+	}
+	_, ok := r.supported[Normalize(locale)]
+	return ok
+}
+
+// Chain trả về danh sách locale theo thứ tự ưu tiên, "vi-VN" sẽ kèm thêm "vi"
+func (r *Resolver) Chain(requested ...string) []string {
+	seen := make(map[string]struct{})
+	var chain []string
+	add := func(locale string) {
+		if locale == "" || !r.IsSupported(locale) {
+			return
+		}
+		if _, ok := seen[locale]; ok {
+			return
+		}
+		seen[locale] = struct{}{}
+		chain = append(chain, locale)
+	}
+
+	for _, l := range requested {
+		l = Normalize(l)
+		add(l)
+		if i := strings.Index(l, "-"); i > 0 {
+			add(l[:i])
+		}
+	}
+	for _, l := range r.fallback {
+		add(l)
+	}
+	add(r.defaultLocale)
+	return chain
+}
+
+// ParseAcceptLanguage parse header Accept-Language, sắp xếp theo q giảm dần
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := Normalize(fields[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if v, ok := strings.CutPrefix(f, "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		items = append(items, weighted{locale: locale, q: q})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+
+	locales := make([]string, 0, len(items))
+	for _, item := range items {
+		locales = append(locales, item.locale)
+	}
+	return locales
+}
+
+// Normalize "en_US" -> "en-us"
+func Normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// IsValid chỉ chấp nhận dạng "vi", "en-us"... để an toàn khi dùng làm key trong Mongo
+func IsValid(locale string) bool {
+	parts := strings.Split(Normalize(locale), "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 {
+		return false
+	}
+	for i, p := range parts {
+		if p == "" || len(p) > 8 {
+			return false
+		}
+		for _, r := range p {
+			isLetter := r >= 'a' && r <= 'z'
+			isDigit := r >= '0' && r <= '9'
+			if !isLetter && !(isDigit && i > 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
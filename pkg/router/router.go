@@ -1,34 +1,511 @@
 package router
 
 import (
+	"context"
+	"log"
+	"os"
+	"services-management/internal/gateway"
+	"services-management/internal/middleware"
+	"services-management/internal/sv_management/cache"
+	"services-management/internal/sv_management/changefeed"
+	"services-management/internal/sv_management/events"
+	"services-management/internal/sv_management/eventstore"
 	"services-management/internal/sv_management/handler"
+	"services-management/internal/sv_management/health"
+	"services-management/internal/sv_management/outbox"
 	"services-management/internal/sv_management/repository"
 	"services-management/internal/sv_management/route"
+	"services-management/internal/sv_management/search"
 	service "services-management/internal/sv_management/services"
+	"services-management/internal/sv_management/storage"
+	"services-management/internal/sv_management/tracking"
+	"services-management/internal/sv_management/webhook"
+	"services-management/logger"
+	"services-management/pkg/config"
+	"services-management/pkg/constants"
+	"services-management/pkg/db"
+	"services-management/pkg/i18n"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hashicorp/consul/api"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupRouter(consulClient *api.Client, serviceCollection *mongo.Collection, serviceGroupCollection *mongo.Collection) *gin.Engine {
+// Background tiến trình nền chạy cùng router, dừng theo thứ tự khi server tắt
+type Background struct {
+	cancel   context.CancelFunc
+	eventBus *events.Bus
+	relay    *outbox.Relay
+	tailer   *outbox.Tailer
+	broker   outbox.Broker
+	webhook  *webhook.Dispatcher
+	tracker  tracking.Tracker
+}
+
+// CloseStreams đóng các SSE stream đang mở để server tắt không phải chờ kết nối dài
+func (b *Background) CloseStreams() {
+	b.eventBus.Close()
+}
+
+// Stop dừng worker nền và ghi nốt dữ liệu còn trong bộ nhớ, gọi sau khi server ngừng nhận request
+func (b *Background) Stop() {
+	b.cancel()
+	// tailer chuyển event cho webhook nên dừng trước
+	b.relay.Wait()
+	b.tailer.Wait()
+	if b.broker != nil {
+		if err := b.broker.Close(); err != nil {
+			logger.WriteLogEx("error", "close outbox broker failed", map[string]any{
+				"error": err.Error(),
+			})
+		}
+	}
+	b.webhook.Wait()
+	b.tracker.Close()
+}
+
+// statusPageCacheSize số status page (organization x locale) giữ trong cache
+const statusPageCacheSize = 500
+
+func SetupRouter(consulClient *api.Client, database *mongo.Database) (*gin.Engine, *Background) {
+	// worker nền chạy tới khi Background.Stop
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+
 	r := gin.Default()
+	r.Use(middleware.Environment(config.AppConfig.App.Environment, config.AppConfig.Environments.OverrideHeader))
+	r.Use(middleware.Compress())
 
 	// gateway
-	//userGateway := gateway.NewUserGateway("go-main-service", consulClient)
+	userGateway := gateway.NewUserGateway("go-main-service", consulClient)
+
+	// i18n
+	i18nCfg := config.AppConfig.I18n
+	localeResolver := i18n.NewResolver(i18nCfg.DefaultLocale, i18nCfg.Fallback, i18nCfg.Supported)
+
+	serviceRepo := repository.NewServiceRepository(database.Collection(db.ServiceCollection))
+	serviceGroupRepo := repository.NewServiceGroupRepository(database.Collection(db.ServiceGroupCollection))
+
+	// assets
+	assetRepo := repository.NewAssetRepository(database.Collection(db.AssetCollection))
+	assetService := service.NewAssetService(assetRepo, serviceRepo, serviceGroupRepo, newAssetStorage(), assetMaxSize(), assetPublicUrl())
+	assetHandler := handler.NewAssetHandler(assetService)
+
+	// webhooks nhận event thay đổi catalog
+	webhookRepo := repository.NewWebhookRepository(database.Collection(db.WebhookCollection))
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(database.Collection(db.WebhookDeliveryCollection), webhookRetention())
+	ensureIndexes(webhookDeliveryRepo)
+	webhookDispatcher := webhook.NewDispatcher(webhookRepo, webhookDeliveryRepo, nil, webhookOptions())
+	webhookDispatcher.Start(workerCtx)
+	webhookService := service.NewWebhookService(webhookRepo, webhookDeliveryRepo, webhookDispatcher)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+
+	// event bus trong process: webhook và SSE stream cùng nhận event catalog
+	eventBus := events.NewBus(config.AppConfig.Stream.HistorySize)
+	eventBus.Handle(webhookDispatcher)
+	catalogStreamService := service.NewCatalogStreamService(eventBus, userGateway)
+	heartbeat, retry := streamTimings()
+	catalogStreamHandler := handler.NewCatalogStreamHandler(catalogStreamService, heartbeat, retry)
+
+	// event store: relay ghi event vào stream của từng aggregate, projection dựng read model catalog từ đó
+	leaseRepo := repository.NewLeaseRepository(database.Collection(db.LeaseCollection))
+	eventStore := newEventStore()
+	catalogViewRepo := repository.NewCatalogViewRepository(database.Collection(db.CatalogViewCollection))
+	var catalogProjection *eventstore.Projection
+	var eventStoreBroker outbox.Broker
+	if eventStore != nil {
+		ensureIndexes(catalogViewRepo)
+		checkpointRepo := repository.NewProjectionCheckpointRepository(database.Collection(db.ProjectionCheckpointCollection))
+		catalogProjection = eventstore.NewProjection(eventstore.CatalogProjection, eventStore, eventstore.NewCatalogProjector(catalogViewRepo), checkpointRepo, leaseRepo, eventstore.Options{
+			PollInterval: time.Duration(config.AppConfig.EventStore.PollIntervalMs) * time.Millisecond,
+			BatchSize:    config.AppConfig.EventStore.BatchSize,
+		})
+		catalogProjection.Start(workerCtx)
+		eventStoreBroker = outbox.NewEventStoreBroker(eventStore, catalogProjection.Notify)
+	}
+	projectionService := service.NewProjectionService(eventStore, catalogProjection, catalogViewRepo)
+	projectionHandler := handler.NewProjectionHandler(projectionService)
+
+	// outbox: service layer ghi thay đổi kèm event trong cùng transaction,
+	// relay (một instance) publish lên broker, tailer của mọi instance chuyển event đã publish cho event bus
+	outboxRepo := repository.NewOutboxRepository(database.Collection(db.OutboxCollection), outboxRetention())
+	ensureIndexes(outboxRepo)
+	outboxOptions := outbox.Options{
+		PollInterval: time.Duration(config.AppConfig.Outbox.PollIntervalMs) * time.Millisecond,
+		BatchSize:    config.AppConfig.Outbox.BatchSize,
+	}
+	outboxTailer := outbox.NewTailer(outboxRepo, eventBus, outboxOptions)
+	outboxTailer.Start(workerCtx)
+	outboxBroker := outbox.MultiBroker(newOutboxBroker(), eventStoreBroker)
+	outboxRelay := outbox.NewRelay(outboxRepo, leaseRepo, outboxBroker, outboxTailer.Notify, outboxOptions)
+	outboxRelay.Start(workerCtx)
+	// delta sync: bản ghi thay đổi có seq được ghi trong cùng transaction với thay đổi catalog
+	catalogChangeRepo := repository.NewCatalogChangeRepository(database.Collection(db.CatalogChangeCollection))
+	counterRepo := repository.NewCounterRepository(database.Collection(db.CounterCollection))
+	ensureIndexes(catalogChangeRepo)
+
+	// cache catalog bị vô hiệu sau mỗi thay đổi ghi qua recorder
+	catalogCache := cache.New(newCacheStore(), "catalog", time.Duration(config.AppConfig.Cache.TTLSeconds)*time.Second)
+	eventRecorder := cache.InvalidatingRecorder(
+		changefeed.NewRecorder(outbox.NewRecorder(newTransactor(database.Collection(db.OutboxCollection)), outboxRepo, outboxRelay), catalogChangeRepo, counterRepo),
+		catalogCache,
+	)
 
 	// services group
-	serviceGroupRepo := repository.NewServiceGroupRepository(serviceGroupCollection)
-	serviceGroupService := service.NewSVGroupService(serviceGroupRepo)
+	serviceGroupService := service.NewSVGroupService(serviceGroupRepo, serviceRepo, leaseRepo, assetService, localeResolver, eventRecorder)
 	serviceGroupHandler := handler.NewServiceGroupHandler(serviceGroupService)
 
 	// services
-	serviceRepo := repository.NewServiceRepository(serviceCollection)
-	svManagementService := service.NewSvManagementService(serviceRepo, serviceGroupRepo)
+	ensureIndexes(serviceRepo, serviceGroupRepo)
+
+	// smart collections
+	collectionRepo := repository.NewSmartCollectionRepository(database.Collection(db.SmartCollectionCollection))
+	ensureIndexes(collectionRepo)
+	collectionService := service.NewSmartCollectionService(collectionRepo, serviceRepo, localeResolver, eventRecorder)
+	collectionHandler := handler.NewSmartCollectionHandler(collectionService)
+
+	// search
+	searchEngine := newSearchEngine(serviceRepo, serviceGroupRepo)
+	searchService := service.NewSvSearchService(searchEngine, serviceRepo, serviceGroupRepo, userGateway)
+	searchHandler := handler.NewSearchHandler(searchService)
+
+	svManagementService := service.NewSvManagementService(serviceRepo, serviceGroupRepo, collectionRepo, searchEngine, assetService, localeResolver, config.AppConfig.Environments.Required, eventRecorder, catalogCache)
 	serviceHandler := handler.NewServiceHandler(svManagementService)
 
+	// announcements
+	announcementRepo := repository.NewAnnouncementRepository(database.Collection(db.AnnouncementCollection))
+	ensureIndexes(announcementRepo)
+	announcementService := service.NewAnnouncementService(announcementRepo, serviceRepo, serviceGroupRepo, eventRecorder)
+	announcementHandler := handler.NewAnnouncementHandler(announcementService)
+
+	// user catalog
+	userPreferenceRepo := repository.NewUserPreferenceRepository(database.Collection(db.UserPreferenceCollection))
+	ensureIndexes(userPreferenceRepo)
+	userCatalogService := service.NewUserCatalogService(userPreferenceRepo, serviceRepo, serviceGroupRepo, localeResolver, userGateway, announcementRepo, collectionRepo)
+	userCatalogHandler := handler.NewUserCatalogHandler(userCatalogService)
+
+	// delta sync cho client offline
+	backfillCatalogChanges(catalogChangeRepo, counterRepo, serviceRepo, serviceGroupRepo)
+	catalogSyncService := service.NewCatalogSyncService(catalogChangeRepo, counterRepo, serviceRepo, serviceGroupRepo, localeResolver, userGateway)
+	catalogSyncHandler := handler.NewCatalogSyncHandler(catalogSyncService)
+
+	// ownership + thông tin hỗ trợ
+	ownershipService := service.NewOwnershipService(serviceRepo, userGateway, localeResolver, eventRecorder)
+	ownershipHandler := handler.NewOwnershipHandler(ownershipService)
+
+	// sso
+	ssoNonceRepo := repository.NewSSONonceRepository(database.Collection(db.SSONonceCollection))
+	ensureIndexes(ssoNonceRepo)
+	ssoService := service.NewSSOService(serviceRepo, ssoNonceRepo, eventRecorder)
+	ssoHandler := handler.NewSSOHandler(ssoService)
+
+	// launch + click tracking
+	clickEventRepo := repository.NewClickEventRepository(database.Collection(db.ClickEventCollection))
+	ensureIndexes(clickEventRepo)
+	clickTracker := tracking.NewTracker(clickEventRepo, trackingOptions())
+	launchService := service.NewLaunchService(serviceRepo, clickTracker, userGateway, ssoNonceRepo, serviceGroupRepo, announcementRepo)
+	launchHandler := handler.NewLaunchHandler(launchService)
+
+	// analytics
+	usageRollupRepo := repository.NewUsageRollupRepository(database.Collection(db.UsageRollupCollection))
+	ensureIndexes(usageRollupRepo)
+	rollupJob := tracking.NewRollupJob(clickEventRepo, usageRollupRepo, time.Duration(config.AppConfig.Tracking.RollupIntervalMs)*time.Millisecond)
+	rollupJob.Start(workerCtx)
+	analyticsService := service.NewAnalyticsService(usageRollupRepo, serviceRepo, rollupJob)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+
+	// dependency graph giữa các service
+	dependencyService := service.NewDependencyService(serviceRepo, leaseRepo, eventRecorder)
+	dependencyHandler := handler.NewDependencyHandler(dependencyService)
+
+	// health probe
+	healthCfg := config.AppConfig.Health
+	healthCheckRepo := repository.NewHealthCheckRepository(database.Collection(db.HealthCheckCollection), healthRetention())
+	ensureIndexes(healthCheckRepo)
+	healthJob := health.NewJob(serviceRepo, healthCheckRepo, health.NewProber(nil), health.Options{
+		Interval:    time.Duration(healthCfg.IntervalSeconds) * time.Second,
+		Timeout:     time.Duration(healthCfg.TimeoutMs) * time.Millisecond,
+		Method:      healthCfg.Method,
+		Concurrency: healthCfg.Concurrency,
+		Environment: config.AppConfig.App.Environment,
+		// service chuyển sang down thì báo các service phụ thuộc bị ảnh hưởng
+		OnStatusChange: dependencyService.ReportStatusChange,
+		// health_status/last_checked nằm trong response catalog được cache
+		OnSaved: catalogCache.Invalidate,
+	})
+	if healthCfg.Enabled {
+		healthJob.Start(workerCtx)
+	}
+	healthService := service.NewHealthService(serviceRepo, healthCheckRepo, healthJob, dependencyService, eventRecorder)
+	healthHandler := handler.NewHealthHandler(healthService)
+
+	// status page
+	statusPageService := service.NewStatusPageService(serviceRepo, serviceGroupRepo, healthCheckRepo, localeResolver, newStatusPageStore())
+	statusPageHandler := handler.NewStatusPageHandler(statusPageService)
+
+	// translations
+	translationService := service.NewTranslationService(serviceRepo, serviceGroupRepo, localeResolver, eventRecorder)
+	translationHandler := handler.NewTranslationHandler(translationService)
+
 	// Register routes
-	route.RegisterServiceRoutes(r, serviceHandler, serviceGroupHandler)
+	route.RegisterServiceRoutes(r, serviceHandler, serviceGroupHandler, searchHandler)
+	route.RegisterAssetRoutes(r, assetHandler)
+	route.RegisterTranslationRoutes(r, translationHandler)
+	route.RegisterUserRoutes(r, userCatalogHandler, catalogSyncHandler, searchHandler)
+	route.RegisterLaunchRoutes(r, launchHandler)
+	route.RegisterAnalyticsRoutes(r, analyticsHandler)
+	route.RegisterSSORoutes(r, ssoHandler)
+	route.RegisterHealthRoutes(r, healthHandler)
+	route.RegisterStatusRoutes(r, statusPageHandler)
+	route.RegisterAnnouncementRoutes(r, announcementHandler)
+	route.RegisterWebhookRoutes(r, webhookHandler)
+	route.RegisterStreamRoutes(r, catalogStreamHandler)
+	route.RegisterProjectionRoutes(r, projectionHandler)
+	route.RegisterCollectionRoutes(r, collectionHandler)
+	route.RegisterOwnershipRoutes(r, ownershipHandler)
+	route.RegisterDependencyRoutes(r, dependencyHandler)
 	//route.RegisterRegionRoutes(r, regionHandler)
-	return r
+	return r, &Background{
+		cancel:   cancelWorkers,
+		eventBus: eventBus,
+		relay:    outboxRelay,
+		tailer:   outboxTailer,
+		broker:   outboxBroker,
+		webhook:  webhookDispatcher,
+		tracker:  clickTracker,
+	}
+}
+
+type indexer interface {
+	EnsureIndexes(ctx context.Context) error
+}
+
+func ensureIndexes(repos ...indexer) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, repo := range repos {
+		if err := repo.EnsureIndexes(ctx); err != nil {
+			logger.WriteLogEx("error", "ensure indexes failed", map[string]any{
+				"error": err.Error(),
+			})
+		}
+	}
+}
+
+// backfillCatalogChanges lần đầu chạy thì ghi bản ghi thay đổi cho catalog hiện có
+func backfillCatalogChanges(changes repository.CatalogChangeRepository, counters repository.CounterRepository, serviceRepo repository.ServiceRepository, groupRepo repository.ServiceGroupRepository) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := changefeed.Backfill(ctx, changes, counters, serviceRepo, groupRepo); err != nil {
+		logger.WriteLogEx("error", "backfill catalog changes failed", map[string]any{
+			"error": err.Error(),
+		})
+	}
+}
+
+// newSearchEngine chọn engine theo config, mặc định dùng Mongo text index
+func newSearchEngine(serviceRepo repository.ServiceRepository, serviceGroupRepo repository.ServiceGroupRepository) search.Engine {
+	cfg := config.AppConfig.Search
+	if cfg.Engine != constants.ElasticSearch {
+		return search.NewMongoEngine(serviceRepo, serviceGroupRepo)
+	}
+
+	elasticUrl := cfg.Elastic.Url
+	if env := os.Getenv(constants.ElasticUrl); env != "" {
+		elasticUrl = env
+	}
+	index := cfg.Elastic.Index
+	if index == "" {
+		index = "services"
+	}
+	return search.NewElasticEngine(elasticUrl, index)
+}
+
+// newAssetStorage hiện chỉ hỗ trợ local filesystem
+func newAssetStorage() storage.Storage {
+	cfg := config.AppConfig.Asset
+	if cfg.Storage != "" && cfg.Storage != "local" {
+		log.Fatalf("Unsupported asset storage: %s", cfg.Storage)
+	}
+
+	path := cfg.LocalPath
+	if path == "" {
+		path = "./data/assets"
+	}
+	st, err := storage.NewLocalStorage(path)
+	if err != nil {
+		log.Fatalf("Failed to init asset storage: %v", err)
+	}
+	return st
+}
+
+func assetMaxSize() int64 {
+	if mb := config.AppConfig.Asset.MaxSizeMB; mb > 0 {
+		return mb << 20
+	}
+	return 2 << 20
+}
+
+func assetPublicUrl() string {
+	if url := config.AppConfig.Asset.PublicUrl; url != "" {
+		return url
+	}
+	return "/api/v1/assets"
+}
+
+func trackingOptions() tracking.Options {
+	cfg := config.AppConfig.Tracking
+	return tracking.Options{
+		BufferSize:    cfg.BufferSize,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: time.Duration(cfg.FlushIntervalMs) * time.Millisecond,
+	}
+}
+
+func healthRetention() time.Duration {
+	if days := config.AppConfig.Health.RetentionDays; days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return 30 * 24 * time.Hour
+}
+
+func webhookOptions() webhook.Options {
+	cfg := config.AppConfig.Webhook
+	return webhook.Options{
+		PollInterval: time.Duration(cfg.PollIntervalMs) * time.Millisecond,
+		Timeout:      time.Duration(cfg.TimeoutMs) * time.Millisecond,
+		MaxAttempts:  cfg.MaxAttempts,
+		BackoffBase:  time.Duration(cfg.BackoffBaseMs) * time.Millisecond,
+		BackoffMax:   time.Duration(cfg.BackoffMaxMs) * time.Millisecond,
+		Concurrency:  cfg.Concurrency,
+	}
+}
+
+func webhookRetention() time.Duration {
+	if days := config.AppConfig.Webhook.RetentionDays; days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return 30 * 24 * time.Hour
+}
+
+func streamTimings() (heartbeat, retry time.Duration) {
+	cfg := config.AppConfig.Stream
+	heartbeat, retry = 25*time.Second, 3*time.Second
+	if cfg.HeartbeatSeconds > 0 {
+		heartbeat = time.Duration(cfg.HeartbeatSeconds) * time.Second
+	}
+	if cfg.RetryMs > 0 {
+		retry = time.Duration(cfg.RetryMs) * time.Millisecond
+	}
+	return heartbeat, retry
+}
+
+// newOutboxBroker broker mà relay publish event lên, nil thì event chỉ đi trong process
+func newOutboxBroker() outbox.Broker {
+	cfg := config.AppConfig.Outbox
+	if cfg.Broker == "" {
+		return nil
+	}
+	if cfg.Broker != constants.Kafka {
+		log.Fatalf("Unsupported outbox broker: %s", cfg.Broker)
+	}
+
+	brokers := cfg.Kafka.Brokers
+	if env := os.Getenv(constants.KafkaBrokers); env != "" {
+		brokers = strings.Split(env, ",")
+	}
+	topic := cfg.Kafka.Topic
+	if topic == "" {
+		topic = "services-management.catalog"
+	}
+	return outbox.NewKafkaBroker(brokers, topic)
+}
+
+// newEventStore chọn event store theo config, nil là tắt event store và projection
+func newEventStore() eventstore.Store {
+	cfg := config.AppConfig.EventStore
+	switch cfg.Store {
+	case "":
+		return nil
+	case "memory":
+		return eventstore.NewMemoryStore()
+	case "esdb":
+		connectionString := cfg.ConnectionString
+		if env := os.Getenv(constants.EventStoreConnectionString); env != "" {
+			connectionString = env
+		}
+		store, err := eventstore.NewESDBStore(connectionString)
+		if err != nil {
+			log.Fatalf("Failed to connect to EventStoreDB: %v", err)
+		}
+		return store
+	default:
+		log.Fatalf("Unsupported event store: %s", cfg.Store)
+		return nil
+	}
+}
+
+// newCacheStore Redis khi có địa chỉ (dùng chung giữa các instance), mặc định LRU trong process
+func newCacheStore() cache.Store {
+	cfg := config.AppConfig.Cache
+	addr := cfg.Redis.Addr
+	if env := os.Getenv(constants.RedisAddr); env != "" {
+		addr = env
+	}
+	if addr != "" {
+		return cache.NewRedisStore(addr, cfg.Redis.Password, cfg.Redis.DB, cfg.Redis.Prefix)
+	}
+
+	size := cfg.Size
+	if size <= 0 {
+		size = 2000
+	}
+	store, err := cache.NewLRUStore(size)
+	if err != nil {
+		log.Fatalf("Failed to create cache: %v", err)
+	}
+	return store
+}
+
+// newStatusPageStore status page công khai nên giữ tối đa statusPageCacheSize trang trong process
+func newStatusPageStore() cache.Store {
+	store, err := cache.NewLRUStore(statusPageCacheSize)
+	if err != nil {
+		log.Fatalf("Failed to create status page cache: %v", err)
+	}
+	return store
+}
+
+// newTransactor bật transaction mà MongoDB không chạy được transaction thì dừng khởi động,
+// không âm thầm ghi outbox ngoài transaction. Chỉ tắt khi cấu hình outbox.transactions: false
+func newTransactor(collection *mongo.Collection) repository.Transactor {
+	if !config.AppConfig.Outbox.Transactions {
+		logger.WriteLogEx("warn", "outbox transactions are disabled: catalog changes and outbox events are written separately, "+
+			"an event is lost if the process stops between the two writes", nil)
+		log.Println("WARNING: outbox.transactions is false, catalog events are not written atomically with catalog changes")
+		return repository.NewTransactor(nil)
+	}
+
+	client := collection.Database().Client()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ok, err := db.SupportsTransactions(ctx, client)
+	if err != nil {
+		log.Fatalf("Failed to check MongoDB transaction support: %v", err)
+	}
+	if !ok {
+		log.Fatalf("outbox.transactions is true but MongoDB is not a replica set: run MongoDB as a replica set " +
+			"(a single-node replica set is enough) or set outbox.transactions: false to accept losing events on crash")
+	}
+	return repository.NewTransactor(client)
+}
+
+func outboxRetention() time.Duration {
+	if days := config.AppConfig.Outbox.RetentionDays; days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
 }
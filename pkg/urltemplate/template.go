@@ -0,0 +1,159 @@
+package urltemplate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Placeholder hỗ trợ trong Service.Url, ví dụ https://lms.example/org/{organization_id}/user/{user_id}
+const (
+	UserID         = "user_id"
+	Username       = "username"
+	Email          = "email"
+	Fullname       = "fullname"
+	OrganizationID = "organization_id"
+	TeacherID      = "teacher_id"
+	StaffID        = "staff_id"
+)
+
+var placeholders = map[string]struct{}{
+	UserID:         {},
+	Username:       {},
+	Email:          {},
+	Fullname:       {},
+	OrganizationID: {},
+	TeacherID:      {},
+	StaffID:        {},
+}
+
+var (
+	ErrInvalidTemplate = errors.New("invalid url template")
+	ErrMissingValue    = errors.New("missing url template value")
+)
+
+// token một đoạn của template, Name rỗng là text giữ nguyên
+type token struct {
+	Text    string
+	Name    string
+	InQuery bool // nằm sau ? hoặc # thì escape kiểu query
+}
+
+func parse(template string) ([]token, error) {
+	var tokens []token
+	inQuery := false
+	for rest := template; rest != ""; {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			tokens = append(tokens, token{Text: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("%w: unexpected '}'", ErrInvalidTemplate)
+		}
+
+		text := rest[:open]
+		if strings.ContainsAny(text, "?#") {
+			inQuery = true
+		}
+		tokens = append(tokens, token{Text: text})
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed '{'", ErrInvalidTemplate)
+		}
+		name := rest[open+1 : open+end]
+		if _, ok := placeholders[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidTemplate, name)
+		}
+		tokens = append(tokens, token{Name: name, InQuery: inQuery})
+		rest = rest[open+end+1:]
+	}
+	return tokens, nil
+}
+
+// HasPlaceholders url có cần render theo user không
+func HasPlaceholders(template string) bool {
+	return strings.ContainsAny(template, "{}")
+}
+
+// Names các placeholder template dùng, không trùng lặp
+func Names(template string) []string {
+	tokens, err := parse(template)
+	if err != nil {
+		return nil
+	}
+	seen := map[string]struct{}{}
+	var names []string
+	for _, t := range tokens {
+		if _, ok := seen[t.Name]; t.Name != "" && !ok {
+			seen[t.Name] = struct{}{}
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// Validate kiểm tra template lúc upload. Url có placeholder phải là http(s) tuyệt đối
+// và placeholder không được nằm trong scheme/host để không redirect sang host do user quyết định
+func Validate(template string) error {
+	tokens, err := parse(template)
+	if err != nil {
+		return err
+	}
+	if !HasPlaceholders(template) {
+		return nil
+	}
+
+	sample := render(tokens, func(string) string { return "x" })
+	u, err := url.Parse(sample)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: placeholders require an absolute http(s) url", ErrInvalidTemplate)
+	}
+	if !strings.HasPrefix(template, u.Scheme+"://"+u.Host) {
+		return fmt.Errorf("%w: placeholders are not allowed in scheme or host", ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// Render thay placeholder bằng giá trị đã escape. lookup trả về false khi không có giá trị
+func Render(template string, lookup func(name string) (string, bool)) (string, error) {
+	tokens, err := parse(template)
+	if err != nil {
+		return "", err
+	}
+
+	var missing string
+	result := render(tokens, func(name string) string {
+		value, ok := lookup(name)
+		if (!ok || value == "") && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("%w: {%s}", ErrMissingValue, missing)
+	}
+	return result, nil
+}
+
+func render(tokens []token, value func(name string) string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		if t.Name == "" {
+			b.WriteString(t.Text)
+			continue
+		}
+		v := value(t.Name)
+		if t.InQuery {
+			b.WriteString(url.QueryEscape(v))
+		} else {
+			b.WriteString(url.PathEscape(v))
+		}
+	}
+	return b.String()
+}